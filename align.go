@@ -0,0 +1,66 @@
+package metaphone3
+
+/**
+ * Span is a half open range [Start, End) of rune offsets in
+ * the word passed to the encoder, before upper casing.
+ */
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+/**
+ * Alignment is the result of EncodeAligned: the keys that Encode
+ * returns, plus for every key character the span of input that
+ * produced it.
+ */
+type Alignment struct {
+	/** Primary key, as returned by Encode. */
+	Primary string `json:"primary"`
+
+	/** Secondary key, as returned by Encode (empty if there is none). */
+	Secondary string `json:"secondary"`
+
+	/** PrimarySpans[i] is the input that produced Primary[i]. */
+	PrimarySpans []Span `json:"primarySpans"`
+
+	/** SecondarySpans[i] is the input that produced Secondary[i]. */
+	SecondarySpans []Span `json:"secondarySpans"`
+}
+
+/**
+ * Encodes word like Encode, and reports which runes of word
+ * produced each character of the keys. This is meant for
+ * highlighting the matching part of a name in a user interface.
+ *
+ * A key character produced by a rule that looked at several
+ * letters at once (e.g. "SCH" -> 'X') is aligned with all of them.
+ */
+func (m *M3) EncodeAligned(word string) Alignment {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	s.runTraced(word)
+
+	var a Alignment
+	a.Primary, a.Secondary = truncateKeys(string(s.primary), string(s.secondary), s.metaphLength)
+
+	for _, step := range s.steps {
+		span := Span{Start: step.Start, End: step.End}
+		for range step.Primary {
+			a.PrimarySpans = append(a.PrimarySpans, span)
+		}
+		for range step.Alternate {
+			a.SecondarySpans = append(a.SecondarySpans, span)
+		}
+	}
+
+	if len(a.PrimarySpans) > len(a.Primary) {
+		a.PrimarySpans = a.PrimarySpans[:len(a.Primary)]
+	}
+	if len(a.SecondarySpans) > len(a.Secondary) {
+		a.SecondarySpans = a.SecondarySpans[:len(a.Secondary)]
+	}
+
+	return a
+}
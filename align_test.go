@@ -0,0 +1,124 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEncodeAligned(t *testing.T) {
+	plain := New()
+	short, err := NewWithOptions(WithKeyLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ligatures, err := NewWithOptions(WithNormalization(Normalization{ExpandLigatures: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		m    *M3
+		word string
+		want Alignment
+	}{
+		{"SCH", plain, "Schmidt", Alignment{
+			Primary:      "XMT",
+			PrimarySpans: []Span{{0, 3}, {3, 4}, {5, 7}},
+		}},
+		{"TH", plain, "Smith", Alignment{
+			Primary: "SM0", Secondary: "XMT",
+			PrimarySpans:   []Span{{0, 1}, {1, 2}, {3, 5}},
+			SecondarySpans: []Span{{0, 1}, {1, 2}, {3, 5}},
+		}},
+		{"TH as T", plain, "Thomas", Alignment{
+			Primary:      "TMS",
+			PrimarySpans: []Span{{0, 2}, {3, 4}, {5, 6}},
+		}},
+		{"double consonants", plain, "Bennett", Alignment{
+			Primary:      "PNT",
+			PrimarySpans: []Span{{0, 1}, {2, 4}, {5, 7}},
+		}},
+		{"double S and L", plain, "Russell", Alignment{
+			Primary:      "RSL",
+			PrimarySpans: []Span{{0, 1}, {2, 4}, {5, 7}},
+		}},
+		{"ß is one rune", plain, "Straße", Alignment{
+			Primary:      "STRS",
+			PrimarySpans: []Span{{0, 1}, {1, 2}, {2, 3}, {4, 5}},
+		}},
+		{"Œ as a vowel", plain, "Œuvre", Alignment{
+			Primary:      "AFR",
+			PrimarySpans: []Span{{0, 2}, {2, 3}, {3, 4}},
+		}},
+		{"Œ expanded", ligatures, "Cœur", Alignment{
+			Primary:      "KR",
+			PrimarySpans: []Span{{0, 1}, {3, 4}},
+		}},
+		// F and I both come from the ligature; F must
+		// still cover it
+		{"ﬁ expanded", ligatures, "ﬁsher", Alignment{
+			Primary:      "FXR",
+			PrimarySpans: []Span{{0, 1}, {1, 3}, {4, 5}},
+		}},
+		{"truncated", short, "Wojciechowski", Alignment{
+			Primary: "ASKS", Secondary: "FSXF",
+			PrimarySpans:   []Span{{0, 2}, {3, 5}, {6, 8}, {10, 11}},
+			SecondarySpans: []Span{{0, 2}, {3, 5}, {6, 8}, {9, 10}},
+		}},
+		{"empty", plain, "", Alignment{}},
+	} {
+		got := test.m.EncodeAligned(test.word)
+		if len(got.SecondarySpans) == 0 {
+			got.SecondarySpans = nil // no matter whether empty or nil
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: EncodeAligned(%q) = %+v, want %+v", test.name, test.word, got, test.want)
+		}
+
+		primary, secondary := test.m.Encode(test.word)
+		if got.Primary != primary || got.Secondary != secondary {
+			t.Errorf("%s: EncodeAligned(%q) keys %q, %q, Encode gives %q, %q", test.name, test.word, got.Primary, got.Secondary, primary, secondary)
+		}
+	}
+}
+
+/**
+ * Checks over the golden corpus, with and without normalization,
+ * that there is one span per key character and that every span is
+ * a non-empty part of the word, in order.
+ */
+func TestEncodeAlignedSpans(t *testing.T) {
+	var encoders []*M3
+	for _, n := range []Normalization{{}, {FoldWidth: true, Compose: true, ExpandLigatures: true, StripAccents: true, Punctuation: PunctuationDrop}} {
+		m, err := NewWithOptions(WithKeyLength(6), WithNormalization(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoders = append(encoders, m)
+	}
+
+	for _, word := range exceptionTestWords(t) {
+		n := utf8.RuneCountInString(word)
+		for _, m := range encoders {
+			a := m.EncodeAligned(word)
+			for _, key := range []struct {
+				name  string
+				key   string
+				spans []Span
+			}{{"primary", a.Primary, a.PrimarySpans}, {"secondary", a.Secondary, a.SecondarySpans}} {
+				if len(key.spans) != len(key.key) {
+					t.Errorf("%v: EncodeAligned(%q) %s %q has %d spans", m.Config().Normalization, word, key.name, key.key, len(key.spans))
+					continue
+				}
+				for i, s := range key.spans {
+					if s.Start < 0 || s.End > n || s.Start >= s.End || (i > 0 && s.Start < key.spans[i-1].Start) {
+						t.Errorf("%v: EncodeAligned(%q) %s span %d is %v", m.Config().Normalization, word, key.name, i, s)
+						break
+					}
+				}
+			}
+		}
+	}
+}
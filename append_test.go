@@ -0,0 +1,60 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+func TestAppendEncodeMatchesEncode(t *testing.T) {
+	for _, m := range encodersForModes(t, WithNormalization(Normalization{Compose: true, StripAccents: true})) {
+		for _, word := range []string{"Smith", "Schmidt", "Wojciechowski", "Muñoz", "Straße", "Ðorđe", "a", ""} {
+			primary, alternate := m.Encode(word)
+			p, a := m.AppendEncode([]byte("x"), []byte("y"), []byte(word))
+			if string(p) != "x"+primary || string(a) != "y"+alternate {
+				t.Errorf("%v: AppendEncode(%q) = %q, %q, want %q, %q", m.Config(), word, p, a, "x"+primary, "y"+alternate)
+			}
+		}
+	}
+}
+
+func TestAppendEncodeAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector makes sync.Pool drop working states")
+	}
+
+	words := [][]byte{
+		[]byte("Wojciechowski"), []byte("Schwarzenegger"), []byte("Muñoz"),
+		[]byte("Straße"), []byte("Dvořák"), []byte("Ångström"), []byte(""),
+	}
+
+	for _, c := range []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"vowels exact", []Option{WithEncodeVowels(true), WithEncodeExact(true)}},
+		{"long keys", []Option{WithKeyLength(MAX_KEY_ALLOCATION)}},
+		{"normalization", []Option{WithNormalization(Normalization{
+			FoldWidth: true, Compose: true, ExpandLigatures: true,
+			Punctuation: PunctuationDrop, StripAccents: true,
+		})}},
+	} {
+		m, err := NewWithOptions(c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		primary := make([]byte, 0, MAX_KEY_ALLOCATION)
+		alternate := make([]byte, 0, MAX_KEY_ALLOCATION)
+		for _, word := range words {
+			// warm up the pool of working state
+			m.AppendEncode(primary, alternate, word)
+
+			allocs := testing.AllocsPerRun(100, func() {
+				m.AppendEncode(primary[:0], alternate[:0], word)
+			})
+			if allocs != 0 {
+				t.Errorf("%s: AppendEncode(%q) allocates %v times per call, want 0", c.name, word, allocs)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package metaphone3
+
+import (
+	"regexp"
+	"strings"
+)
+
+/** Length of Caverphone 2.0 keys. */
+const CAVERPHONE_LENGTH = 10
+
+/**
+ * Caverphone2 encodes words with Caverphone 2.0, designed by David
+ * Hood for matching the names of 19th and 20th century New Zealand
+ * electoral rolls. Keys are ten characters long, padded with '1',
+ * e.g.
+ *
+ *   "Henrichsen", "Henricsson", "Hinrichsen" -> "ANRKSN1111",
+ *   "Maclaverty", "Mccleverty", "Mclafferty" -> "MKLFTA1111",
+ *   "Stevenson" -> "STFNSN1111", "Peter" -> "PTA1111111",
+ *   "Izchaki" -> "ASKKA11111", "Whitlam" -> "WTLM111111".
+ *
+ * Only the letters 'A' to 'Z' are coded; other characters are
+ * ignored.
+ *
+ * A Caverphone2 is safe for concurrent use.
+ */
+type Caverphone2 struct{}
+
+/**
+ * Constructor, default.
+ */
+func NewCaverphone2() *Caverphone2 { return &Caverphone2{} }
+
+/**
+ * Encodes word to its Caverphone 2.0 key. Caverphone has no
+ * alternate key, so alternate is always empty; a word without any
+ * letter 'A' to 'Z' gives an empty key.
+ */
+func (c *Caverphone2) Encode(word string) (primary, alternate string) {
+	var b strings.Builder
+	for _, r := range word {
+		if l, ok := soundexLetter(r); ok {
+			b.WriteByte(l - 'A' + 'a')
+		}
+	}
+	if b.Len() == 0 {
+		return "", ""
+	}
+
+	txt := b.String()
+	for _, step := range caverphoneSteps {
+		txt = step.re.ReplaceAllLiteralString(txt, step.repl)
+	}
+
+	txt += strings.Repeat("1", CAVERPHONE_LENGTH)
+	return txt[:CAVERPHONE_LENGTH], ""
+}
+
+/**
+ * A rewrite of the Caverphone 2.0 specification: every match of
+ * re is replaced with repl.
+ */
+type caverphoneStep struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+/** The rewrites of caverphoneRewrites, compiled. */
+var caverphoneSteps []caverphoneStep
+
+func init() {
+	caverphoneSteps = make([]caverphoneStep, len(caverphoneRewrites))
+	for i, rw := range caverphoneRewrites {
+		caverphoneSteps[i] = caverphoneStep{regexp.MustCompile(rw[0]), rw[1]}
+	}
+}
+
+/**
+ * The rewrites of the Caverphone 2.0 specification, applied in
+ * order to the lower cased word. Upper case letters in the result
+ * are final; '2' and '3' are placeholders removed at the end,
+ * except for a final '3', which becomes 'A'.
+ */
+var caverphoneRewrites = [...][2]string{
+	{"e$", ""},
+	{"^cough", "cou2f"}, {"^rough", "rou2f"}, {"^tough", "tou2f"},
+	{"^enough", "enou2f"}, {"^trough", "trou2f"},
+	{"^gn", "2n"},
+	{"mb$", "m2"},
+	{"cq", "2q"}, {"ci", "si"}, {"ce", "se"}, {"cy", "sy"},
+	{"tch", "2ch"},
+	{"c", "k"}, {"q", "k"}, {"x", "k"}, {"v", "f"},
+	{"dg", "2g"},
+	{"tio", "sio"}, {"tia", "sia"},
+	{"d", "t"}, {"ph", "fh"}, {"b", "p"}, {"sh", "s2"}, {"z", "s"},
+	{"^[aeiou]", "A"}, {"[aeiou]", "3"},
+	{"j", "y"},
+	{"^y3", "Y3"}, {"^y", "A"}, {"y", "3"},
+	{"3gh3", "3kh3"}, {"gh", "22"}, {"g", "k"},
+	{"s+", "S"}, {"t+", "T"}, {"p+", "P"}, {"k+", "K"},
+	{"f+", "F"}, {"m+", "M"}, {"n+", "N"},
+	{"w3", "W3"}, {"wh3", "Wh3"}, {"w$", "3"}, {"w", "2"},
+	{"^h", "A"}, {"h", "2"},
+	{"r3", "R3"}, {"r$", "3"}, {"r", "2"},
+	{"l3", "L3"}, {"l$", "3"}, {"l", "2"},
+	{"2", ""}, {"3$", "A"}, {"3", ""},
+}
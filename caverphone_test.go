@@ -0,0 +1,36 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+var caverphoneTests = []struct {
+	word, key string
+}{
+	{"Henrichsen", "ANRKSN1111"},
+	{"Henricsson", "ANRKSN1111"},
+	{"Hinrichsen", "ANRKSN1111"},
+	{"Maclaverty", "MKLFTA1111"},
+	{"Mccleverty", "MKLFTA1111"},
+	{"Mclafferty", "MKLFTA1111"},
+	{"Stevenson", "STFNSN1111"},
+	{"Peter", "PTA1111111"},
+	{"Izchaki", "ASKKA11111"},
+	{"Whitlam", "WTLM111111"},
+	{"Mb", "M111111111"},
+	{"Lee", "LA11111111"},
+	{"Tough", "TF11111111"},
+	{"Enough", "ANF1111111"},
+	{"Stevenson-Stevenson", "STFNSNSTFN"},
+	{"", ""},
+	{"123", ""},
+}
+
+func TestCaverphone2(t *testing.T) {
+	c := NewCaverphone2()
+	for _, test := range caverphoneTests {
+		if key, alternate := c.Encode(test.word); key != test.key || alternate != "" {
+			t.Errorf("Encode(%q) = %q, %q, want %q", test.word, key, alternate, test.key)
+		}
+	}
+}
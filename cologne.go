@@ -0,0 +1,139 @@
+package metaphone3
+
+import (
+	"unicode"
+)
+
+/**
+ * ColognePhonetic encodes words with the Kölner Phonetik of Hans
+ * Joachim Postel, which is designed for German spellings the way
+ * Soundex is for English ones. Keys are strings of digits of any
+ * length, e.g.
+ *
+ *   "Müller-Lüdenscheidt" -> "65752682", "Wikipedia" -> "3412",
+ *   "Breschnew" -> "17863", "Meyer", "Maier", "Mayr" -> "67".
+ *
+ * Umlauts are coded as their base vowels and 'ß' as 'S', whether
+ * they are precomposed or written with a combining diaeresis. Other
+ * accents are stripped; characters that are not letters are ignored.
+ *
+ * A ColognePhonetic is safe for concurrent use.
+ */
+type ColognePhonetic struct{}
+
+/**
+ * Constructor, default.
+ */
+func NewColognePhonetic() *ColognePhonetic { return &ColognePhonetic{} }
+
+/**
+ * Encodes word to its Kölner Phonetik key. The algorithm has no
+ * alternate key, so alternate is always empty.
+ */
+func (c *ColognePhonetic) Encode(word string) (primary, alternate string) {
+	in := cologneClean(word)
+
+	var code []byte
+	var lastCode, lastChar byte
+
+	// put appends d unless it repeats the previous digit; '0'
+	// (vowels) is kept only at the start, and '-' ('H') only
+	// separates repeats
+	put := func(d byte) {
+		if d != '-' && d != lastCode && (d != '0' || len(code) == 0) {
+			code = append(code, d)
+		}
+		lastCode = d
+	}
+
+	for i, chr := range in {
+		next := byte('-')
+		if i+1 < len(in) {
+			next = in[i+1]
+		}
+
+		switch {
+		case chr == 'A' || chr == 'E' || chr == 'I' || chr == 'J' || chr == 'O' || chr == 'U' || chr == 'Y':
+			put('0')
+		case chr == 'B' || (chr == 'P' && next != 'H'):
+			put('1')
+		case (chr == 'D' || chr == 'T') && !cologneIn(next, "CSZ"):
+			put('2')
+		case chr == 'F' || chr == 'P' || chr == 'V' || chr == 'W':
+			put('3')
+		case chr == 'G' || chr == 'K' || chr == 'Q':
+			put('4')
+		case chr == 'X' && !cologneIn(lastChar, "CKQ"):
+			put('4')
+			put('8')
+		case chr == 'S' || chr == 'Z':
+			put('8')
+		case chr == 'C':
+			if i == 0 {
+				// at the start of the word
+				if cologneIn(next, "AHKLOQRUX") {
+					put('4')
+				} else {
+					put('8')
+				}
+			} else if cologneIn(lastChar, "SZ") || !cologneIn(next, "AHKOQUX") {
+				put('8')
+			} else {
+				put('4')
+			}
+		case chr == 'D' || chr == 'T' || chr == 'X':
+			put('8')
+		case chr == 'R':
+			put('7')
+		case chr == 'L':
+			put('5')
+		case chr == 'M' || chr == 'N':
+			put('6')
+		case chr == 'H':
+			put('-')
+		}
+
+		lastChar = chr
+	}
+
+	return string(code), ""
+}
+
+/**
+ * Tests whether c is one of the letters in set.
+ */
+func cologneIn(c byte, set string) bool {
+	for i := 0; i < len(set); i++ {
+		if set[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Upper cases word, folds umlauts and other accented letters onto
+ * their base letters and 'ß' onto 'S', and keeps only the letters
+ * 'A' to 'Z'.
+ */
+func cologneClean(word string) []byte {
+	src := []rune(word)
+	folded, _ := cologneNormalization.Normalize(make([]rune, 0, len(src)), make([]int, 0, len(src)), src)
+
+	in := make([]byte, 0, len(folded))
+	for _, r := range folded {
+		if r == 'ß' || r == 'ẞ' {
+			r = 'S'
+		}
+		if r = unicode.ToUpper(r); r >= 'A' && r <= 'Z' {
+			in = append(in, byte(r))
+		}
+	}
+	return in
+}
+
+/** Accent folding applied before encoding. */
+var cologneNormalization = Normalization{
+	Compose:      true,
+	StripAccents: true,
+}
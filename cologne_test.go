@@ -0,0 +1,57 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+var cologneTests = []struct {
+	word, key string
+}{
+	{"Müller-Lüdenscheidt", "65752682"},
+	{"Wikipedia", "3412"},
+	{"Breschnew", "17863"},
+	{"Meyer", "67"},
+	{"Maier", "67"},
+	{"Mayr", "67"},
+	{"Mair", "67"},
+	{"Müller", "657"},
+	{"Mu\u0308ller", "657"}, // combining diaeresis
+	{"schmidt", "862"},
+	{"schneider", "8627"},
+	{"fischer", "387"},
+	{"weber", "317"},
+	{"wagner", "3467"},
+	{"becker", "147"},
+	{"hoffmann", "0366"},
+	{"Straße", "8278"},
+	{"Strasse", "8278"},
+	{"Xaver", "4837"},
+	{"Axel", "0485"},
+	{"Dachs", "248"},
+	{"Matz", "68"},
+
+	// 'C' at the start of the word
+	{"Carl", "475"},
+	{"Christoph", "47823"},
+	{"Celle", "85"},
+	{"Cäsar", "487"},
+	{"-Carl", "475"},
+
+	// 'C' after a leading 'H', which adds no digit, is not at the
+	// start: before 'R' it is '8' rather than '4'
+	{"Hcr", "87"},
+	{"Hcarl", "475"},
+	{"Hcl", "85"},
+
+	{"", ""},
+	{"123", ""},
+}
+
+func TestColognePhonetic(t *testing.T) {
+	c := NewColognePhonetic()
+	for _, test := range cologneTests {
+		if key, alternate := c.Encode(test.word); key != test.key || alternate != "" {
+			t.Errorf("Encode(%q) = %q, %q, want %q", test.word, key, alternate, test.key)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package metaphone3
+
+import (
+	"fmt"
+)
+
+/**
+ * Config is the complete set of encoding settings of an M3 encoder.
+ * It is a plain comparable value: two encoders with equal Configs
+ * produce identical keys, so a Config can be compared, printed, and
+ * persisted (as JSON) alongside the keys it produced.
+ */
+type Config struct {
+	/** Encode non-initial vowels. See SetEncodeVowels. */
+	EncodeVowels bool `json:"encodeVowels"`
+
+	/** Encode consonants as exactly as possible. See SetEncodeExact. */
+	EncodeExact bool `json:"encodeExact"`
+
+	/** Length keys are truncated to. */
+	KeyLength int `json:"keyLength"`
+
+	/** Largest KeyLength this encoder accepts. */
+	MaxKeyLength int `json:"maxKeyLength"`
+
+	/** Largest number of keys EncodeAll returns. 0 stands for
+	 * DEFAULT_MAX_KEYS. Does not affect the keys themselves, and
+	 * so is not part of the fingerprint. */
+	MaxKeys int `json:"maxKeys"`
+
+	/** Characters besides white space that split a phrase into
+	 * tokens in EncodePhrase. Empty stands for DEFAULT_SEPARATORS,
+	 * the hyphen, which leaves "O'Brien" one token; use " " to
+	 * split at white space only, or e.g. "-/" to split at slashes
+	 * as well. Does not affect the key of any one token, and so is
+	 * not part of the fingerprint. */
+	Separators string `json:"separators"`
+
+	/** Whether EncodeName also takes for particles the words that
+	 * are as often names of their own when written apart: LE, LA,
+	 * DA, DI, DU, DES, DOS, DAS, TEN, TER, MAC and MC. Off by
+	 * default, so that "Le Van Thanh" keeps its family name. Does
+	 * not affect Encode, and so is not part of the fingerprint. */
+	AmbiguousParticles bool `json:"ambiguousParticles"`
+
+	/** Rewriting of the input before encoding. The zero value
+	 * leaves the input alone. */
+	Normalization Normalization `json:"normalization"`
+
+	/** Hash of the customizations an encoder was given besides
+	 * its Config: exception sets other than the defaults, a
+	 * RuleSet, Rules and a Normalizer. Empty if there are none.
+	 * Filled in by M3.Config; the value an encoder is given with
+	 * WithConfig is ignored. An override table is left out, as
+	 * it may change while the encoder uses it; see
+	 * Overrides.Version. */
+	Customization string `json:"customization,omitempty"`
+}
+
+/**
+ * Returns the settings used by New(): no vowel encoding,
+ * approximate consonants, DEFAULT_MAX_KEY_LENGTH long keys,
+ * a limit of MAX_KEY_ALLOCATION and DEFAULT_MAX_KEYS keys
+ * from EncodeAll.
+ */
+func DefaultConfig() Config {
+	return Config{
+		KeyLength:    DEFAULT_MAX_KEY_LENGTH,
+		MaxKeyLength: MAX_KEY_ALLOCATION,
+		MaxKeys:      DEFAULT_MAX_KEYS,
+	}
+}
+
+/**
+ * Checks that the key lengths are usable.
+ *
+ * @return nil if c can be used to encode, an error describing
+ * the first problem found otherwise
+ */
+func (c Config) Validate() error {
+	if c.MaxKeyLength < 1 {
+		return fmt.Errorf("metaphone3: max key length %d is less than 1", c.MaxKeyLength)
+	}
+
+	if c.KeyLength < 1 {
+		return fmt.Errorf("metaphone3: key length %d is less than 1", c.KeyLength)
+	}
+
+	if c.KeyLength > c.MaxKeyLength {
+		return fmt.Errorf("metaphone3: key length %d exceeds max key length %d", c.KeyLength, c.MaxKeyLength)
+	}
+
+	if c.MaxKeys < 0 {
+		return fmt.Errorf("metaphone3: max keys %d is negative", c.MaxKeys)
+	}
+
+	return c.Normalization.Validate()
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("metaphone3.Config{EncodeVowels:%t EncodeExact:%t KeyLength:%d MaxKeyLength:%d MaxKeys:%d Separators:%q AmbiguousParticles:%t Normalization:%+v Customization:%q}",
+		c.EncodeVowels, c.EncodeExact, c.KeyLength, c.MaxKeyLength, c.MaxKeys, c.Separators, c.AmbiguousParticles, c.Normalization, c.Customization)
+}
+
+/**
+ * Option adjusts an encoder being built by NewWithOptions.
+ */
+type Option func(*M3)
+
+/**
+ * Replaces all settings with c. Options after this one
+ * still apply on top of it.
+ */
+func WithConfig(c Config) Option {
+	return func(m *M3) { m.config = c }
+}
+
+/** Sets Config.EncodeVowels. */
+func WithEncodeVowels(encodeVowels bool) Option {
+	return func(m *M3) { m.config.EncodeVowels = encodeVowels }
+}
+
+/** Sets Config.EncodeExact. */
+func WithEncodeExact(encodeExact bool) Option {
+	return func(m *M3) { m.config.EncodeExact = encodeExact }
+}
+
+/** Sets Config.KeyLength. */
+func WithKeyLength(keyLength int) Option {
+	return func(m *M3) { m.config.KeyLength = keyLength }
+}
+
+/** Sets Config.MaxKeyLength. */
+func WithMaxKeyLength(maxKeyLength int) Option {
+	return func(m *M3) { m.config.MaxKeyLength = maxKeyLength }
+}
+
+/** Sets Config.MaxKeys. */
+func WithMaxKeys(maxKeys int) Option {
+	return func(m *M3) { m.config.MaxKeys = maxKeys }
+}
+
+/** Sets Config.Separators. */
+func WithSeparators(separators string) Option {
+	return func(m *M3) { m.config.Separators = separators }
+}
+
+/** Sets Config.AmbiguousParticles. */
+func WithAmbiguousParticles(ambiguousParticles bool) Option {
+	return func(m *M3) { m.config.AmbiguousParticles = ambiguousParticles }
+}
+
+/** Sets Config.Normalization. */
+func WithNormalization(n Normalization) Option {
+	return func(m *M3) { m.config.Normalization = n }
+}
+
+/**
+ * Runs words through n instead of Config.Normalization. The
+ * fingerprint knows n only by its type and String method, if it
+ * has one, so a Normalizer whose behaviour changes should say so
+ * in its String.
+ */
+func WithNormalizer(n Normalizer) Option {
+	return func(m *M3) { m.normalizer = n }
+}
+
+/**
+ * Uses the exception sets of e, with nil sets standing for the
+ * defaults. Sets with entries other than the defaults change
+ * the fingerprint (see Config.Customization).
+ */
+func WithExceptions(e Exceptions) Option {
+	return func(m *M3) { m.exceptions = e.withDefaults() }
+}
+
+/**
+ * Looks every word up in o before encoding it, and uses the keys
+ * found there instead of applying the rules. The entries of o are
+ * not part of the fingerprint, so that keys tagged before and after
+ * a reload still compare; store o.Version() next to the keys to
+ * tell the tables apart.
+ */
+func WithOverrides(o *Overrides) Option {
+	return func(m *M3) { m.overrides, m.overrideIndex = o, new(overrideIndex) }
+}
+
+/**
+ * Tries the rules of rs at each letter before the built-in
+ * handlers, and after any Rule registered with WithRule. The
+ * rules of rs are part of the fingerprint.
+ */
+func WithRules(rs *RuleSet) Option {
+	return func(m *M3) { m.rules = rs }
+}
+
+/**
+ * Constructor taking options. Starts from DefaultConfig(),
+ * applies opts in order, and validates the result.
+ *
+ * @return the configured encoder, or an error from Config.Validate
+ */
+func NewWithOptions(opts ...Option) (*M3, error) {
+	m := New()
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if err := m.config.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.settle()
+	return m, nil
+}
+
+/**
+ * Returns a copy of the encoder's current settings.
+ */
+func (m *M3) Config() Config {
+	c := m.config
+	c.Customization = m.customized
+	return c
+}
+
+/**
+ * Returns the exception sets the encoder uses.
+ */
+func (m *M3) Exceptions() Exceptions { return m.exceptions }
@@ -0,0 +1,128 @@
+package metaphone3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig().Validate() = %v", err)
+	}
+
+	for _, test := range []struct {
+		change func(*Config)
+		want   string
+	}{
+		{func(c *Config) { c.MaxKeyLength = 0 }, "max key length 0 is less than 1"},
+		{func(c *Config) { c.MaxKeyLength = -3 }, "max key length -3 is less than 1"},
+		{func(c *Config) { c.KeyLength = 0 }, "key length 0 is less than 1"},
+		{func(c *Config) { c.KeyLength = -1 }, "key length -1 is less than 1"},
+		{func(c *Config) { c.KeyLength = MAX_KEY_ALLOCATION + 1 }, "key length 33 exceeds max key length 32"},
+		{func(c *Config) { c.KeyLength, c.MaxKeyLength = 5, 4 }, "key length 5 exceeds max key length 4"},
+		{func(c *Config) { c.MaxKeys = -1 }, "max keys -1 is negative"},
+		{func(c *Config) { c.Normalization.Punctuation = PunctuationDrop + 1 }, "unknown punctuation mode"},
+	} {
+		c := DefaultConfig()
+		test.change(&c)
+		err := c.Validate()
+		if err == nil {
+			t.Errorf("%v: Validate() = nil, want %q", c, test.want)
+			continue
+		}
+		if msg := err.Error(); !strings.HasPrefix(msg, "metaphone3: ") || !strings.Contains(msg, test.want) {
+			t.Errorf("%v: Validate() = %q, want it to contain %q", c, msg, test.want)
+		}
+
+		if m, err := NewWithOptions(WithConfig(c)); err == nil {
+			t.Errorf("NewWithOptions(WithConfig(%v)) = %v, want an error", c, m.Config())
+		}
+	}
+
+	for _, c := range []Config{
+		{KeyLength: 1, MaxKeyLength: 1},
+		{KeyLength: MAX_KEY_ALLOCATION, MaxKeyLength: MAX_KEY_ALLOCATION, MaxKeys: 1},
+		{KeyLength: 4, MaxKeyLength: 100},
+	} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%v: Validate() = %v, want nil", c, err)
+		}
+	}
+}
+
+func TestWithConfigRoundTrip(t *testing.T) {
+	want := Config{
+		EncodeVowels:       true,
+		EncodeExact:        true,
+		KeyLength:          12,
+		MaxKeyLength:       20,
+		MaxKeys:            3,
+		Separators:         "-/",
+		AmbiguousParticles: true,
+		Normalization:      Normalization{Compose: true, StripAccents: true, KeepAccented: "Ñ", Punctuation: PunctuationFold},
+	}
+
+	m, err := NewWithOptions(WithConfig(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Config(); got != want {
+		t.Errorf("Config() = %v, want %v", got, want)
+	}
+
+	// a copy built from the returned Config encodes alike
+	c, err := NewWithOptions(WithConfig(m.Config()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Config(); got != want {
+		t.Errorf("round trip Config() = %v, want %v", got, want)
+	}
+	for _, word := range []string{"Wojciechowski", "Muñoz", "Schwarzenegger"} {
+		if got, want := c.EncodeKey(word), m.EncodeKey(word); got != want {
+			t.Errorf("EncodeKey(%q) = %+v, want %+v", word, got, want)
+		}
+	}
+
+	// Customization is filled in by Config, never taken from WithConfig
+	stale := want
+	stale.Customization = "0123456789abcdef"
+	if m, err := NewWithOptions(WithConfig(stale)); err != nil {
+		t.Fatal(err)
+	} else if got := m.Config().Customization; got != "" {
+		t.Errorf("Customization = %q, want it ignored", got)
+	}
+
+	// options after WithConfig apply on top of it
+	m, err = NewWithOptions(WithConfig(want), WithKeyLength(4), WithEncodeVowels(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.KeyLength, want.EncodeVowels = 4, false
+	if got := m.Config(); got != want {
+		t.Errorf("Config() = %v, want %v", got, want)
+	}
+}
+
+func TestNewWithOptionsRejectsInvalid(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithKeyLength(0)},
+		{WithKeyLength(MAX_KEY_ALLOCATION + 1)},
+		{WithMaxKeyLength(4)},
+		{WithMaxKeys(-1)},
+		{WithNormalization(Normalization{Punctuation: -1})},
+		{WithConfig(Config{})},
+	} {
+		m, err := NewWithOptions(opts...)
+		if err == nil {
+			t.Errorf("NewWithOptions gave %v, want an error", m.Config())
+			continue
+		}
+		if m != nil {
+			t.Errorf("NewWithOptions returned an encoder with error %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "metaphone3: ") {
+			t.Errorf("error %q lacks the metaphone3: prefix", err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package metaphone3
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+/** The golden corpus, see the comment at its top. */
+const goldenCorpus = "testdata/conformance/golden.tsv"
+
+/** The known divergences from the golden corpus, see the comment
+ * at its top. */
+const knownDivergences = "testdata/conformance/divergences.tsv"
+
+/** The keys of one word in the four modes, and for a known
+ * divergence the requests that account for it. */
+type conformanceEntry struct {
+	line     int
+	keys     []string
+	requests string
+}
+
+/** The modes of the columns of the corpus, in order. */
+var conformanceModes = []struct {
+	name          string
+	vowels, exact bool
+}{
+	{"approx", false, false},
+	{"exact", false, true},
+	{"vowels approx", true, false},
+	{"vowels exact", true, true},
+}
+
+/**
+ * Reads a corpus file: a word and its keys in the four modes per
+ * line, followed by extra fields. Comment lines start with '#'. Also
+ * returns the words in file order and the comment lines starting
+ * with "# source:".
+ */
+func readConformance(t *testing.T, name string, extra int) (entries map[string]conformanceEntry, words []string) {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	entries = make(map[string]conformanceEntry)
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if strings.HasPrefix(text, "# source:") {
+			t.Logf("%s %s", name, strings.TrimSpace(text[1:]))
+		}
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) != 1+len(conformanceModes)+extra {
+			t.Fatalf("%s:%d: %d fields, want %d", name, line, len(fields), 1+len(conformanceModes)+extra)
+		}
+		word := fields[0]
+		if _, dup := entries[word]; dup {
+			t.Fatalf("%s:%d: %q listed twice", name, line, word)
+		}
+
+		e := conformanceEntry{line: line, keys: fields[1 : 1+len(conformanceModes)]}
+		if extra > 0 {
+			e.requests = fields[1+len(conformanceModes)]
+		}
+		entries[word] = e
+		words = append(words, word)
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return entries, words
+}
+
+/**
+ * Encodes every word of the golden corpus in the four combinations
+ * of encoding vowels and encoding exact. A key that differs from the
+ * corpus must be listed, with the key this port gives, among the
+ * known divergences; every other difference is reported, as is every
+ * known divergence that no longer differs from the corpus.
+ */
+func TestConformance(t *testing.T) {
+	golden, words := readConformance(t, goldenCorpus, 0)
+	known, _ := readConformance(t, knownDivergences, 1)
+
+	encoders := make([]*M3, len(conformanceModes))
+	for i, mode := range conformanceModes {
+		encoders[i] = New()
+		encoders[i].SetEncodeVowels(mode.vowels)
+		encoders[i].SetEncodeExact(mode.exact)
+	}
+
+	for word, d := range known {
+		g, ok := golden[word]
+		if !ok {
+			t.Errorf("%s:%d: %q is not in the corpus", knownDivergences, d.line, word)
+			continue
+		}
+		if strings.Join(g.keys, "\t") == strings.Join(d.keys, "\t") {
+			t.Errorf("%s:%d: %q does not diverge from the corpus", knownDivergences, d.line, word)
+		}
+	}
+
+	divergences := 0
+	byRequests := make(map[string]int)
+	for _, word := range words {
+		want, file := golden[word], goldenCorpus
+		if d, ok := known[word]; ok {
+			want, file = d, knownDivergences
+			byRequests[d.requests]++
+		}
+
+		for i, m := range encoders {
+			primary, alternate := m.Encode(word)
+			if got := primary + "," + alternate; got != want.keys[i] {
+				t.Errorf("%s:%d: %s Encode(%q) = %s, want %s", file, want.line, conformanceModes[i].name, word, got, want.keys[i])
+				divergences++
+			}
+		}
+	}
+
+	var requests []string
+	for r := range byRequests {
+		requests = append(requests, r)
+	}
+	sort.Strings(requests)
+	for _, r := range requests {
+		t.Logf("%d known divergences from %s", byRequests[r], r)
+	}
+	if divergences > 0 {
+		t.Errorf("%d of %d keys diverge from the corpus and are not known divergences", divergences, len(words)*len(encoders))
+	}
+}
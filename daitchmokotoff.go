@@ -0,0 +1,381 @@
+package metaphone3
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/** Length of Daitch-Mokotoff codes. */
+const DAITCH_MOKOTOFF_LENGTH = 6
+
+/**
+ * DaitchMokotoff encodes words with Daitch-Mokotoff Soundex, which
+ * was designed for the Eastern European and Jewish surnames that
+ * American Soundex codes poorly. Codes are six digits long, e.g.
+ * "Peters" -> "739400".
+ *
+ * Several letter groups, such as "CH", "CK", "J" and "RZ", are
+ * ambiguous and are coded both ways, so a word can have more than
+ * one code; Codes returns them all. Accents are stripped before
+ * encoding, except from the Polish 'Ą', 'Ę' and the Romanian 'Ţ',
+ * which have rules of their own. 'Ț', the same letter written with
+ * a comma below rather than a cedilla, is coded as 'Ţ'.
+ *
+ * A DaitchMokotoff is safe for concurrent use.
+ */
+type DaitchMokotoff struct{}
+
+/**
+ * Constructor, default.
+ */
+func NewDaitchMokotoff() *DaitchMokotoff { return &DaitchMokotoff{} }
+
+/**
+ * Encodes word to its first Daitch-Mokotoff code and, if the word
+ * has more than one, its second. Use Codes to get all of them.
+ */
+func (d *DaitchMokotoff) Encode(word string) (primary, alternate string) {
+	codes := d.Codes(word)
+	switch len(codes) {
+	case 0:
+		return "", ""
+	case 1:
+		return codes[0], ""
+	}
+	return codes[0], codes[1]
+}
+
+/**
+ * Encodes word to all of its Daitch-Mokotoff codes, without
+ * duplicates, in the order the branches of the ambiguous letter
+ * groups are listed in the coding chart.
+ *
+ * @return the codes, or nil if word has no letters
+ */
+func (d *DaitchMokotoff) Codes(word string) []string {
+	in := dmsClean(word)
+	if len(in) == 0 {
+		return nil
+	}
+
+	branches := []dmsBranch{{}}
+	var next []dmsBranch
+	var lastChar rune
+
+	for i := 0; i < len(in); {
+		r := dmsMatch(in[i:])
+		if r == nil {
+			// no rule for this character: skip it
+			i++
+			continue
+		}
+
+		replacements := r.replacements(in, i, lastChar == 0)
+		force := (lastChar == 'M' && in[i] == 'N') || (lastChar == 'N' && in[i] == 'M')
+
+		next = next[:0]
+		for _, b := range branches {
+			for _, replacement := range replacements {
+				next = appendBranch(next, b.add(replacement, force))
+			}
+		}
+		branches, next = next, branches
+
+		lastChar = in[i]
+		i += len(r.pattern)
+	}
+
+	codes := make([]string, 0, len(branches))
+	for _, b := range branches {
+		code := b.code
+		for len(code) < DAITCH_MOKOTOFF_LENGTH {
+			code += "0"
+		}
+		if !containsString(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+/**
+ * Reports whether a and b share a Daitch-Mokotoff code.
+ */
+func (d *DaitchMokotoff) Match(a, b string) bool {
+	codesB := d.Codes(b)
+	for _, code := range d.Codes(a) {
+		if containsString(codesB, code) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Agreement is how Metaphone 3 and Daitch-Mokotoff judge a pair
+ * of names, as returned by M3.CompareDaitchMokotoff.
+ */
+type Agreement struct {
+	/** Whether the Metaphone 3 keys of the names match,
+	 * as Key.Match decides. */
+	M3 bool `json:"m3"`
+
+	/** Whether the names share a Daitch-Mokotoff code. */
+	DaitchMokotoff bool `json:"daitchMokotoff"`
+}
+
+/**
+ * Tests whether both algorithms reached the same verdict.
+ */
+func (a Agreement) Agree() bool { return a.M3 == a.DaitchMokotoff }
+
+/**
+ * Encodes the names a and b with m and with d, and reports whether
+ * each algorithm considers them a match. Pairs on which the two
+ * disagree point at spellings one of them does not cover.
+ */
+func (m *M3) CompareDaitchMokotoff(d *DaitchMokotoff, a, b string) Agreement {
+	// keys from the same M3 always have the same fingerprint
+	m3, _ := m.EncodeKey(a).Match(m.EncodeKey(b))
+
+	return Agreement{
+		M3:             m3,
+		DaitchMokotoff: d.Match(a, b),
+	}
+}
+
+/**
+ * One way of reading the word so far: the code built up, and the
+ * replacement of the last letter group, which is not repeated for
+ * an adjacent group that codes the same.
+ */
+type dmsBranch struct {
+	code string
+	last string
+}
+
+/**
+ * Returns b with replacement added. Unless force is set, a
+ * replacement that the previous one ends with is not repeated.
+ */
+func (b dmsBranch) add(replacement string, force bool) dmsBranch {
+	if (force || !strings.HasSuffix(b.last, replacement)) && len(b.code) < DAITCH_MOKOTOFF_LENGTH {
+		b.code += replacement
+		if len(b.code) > DAITCH_MOKOTOFF_LENGTH {
+			b.code = b.code[:DAITCH_MOKOTOFF_LENGTH]
+		}
+	}
+	b.last = replacement
+	return b
+}
+
+/**
+ * Appends b to branches unless it is already there. Identical
+ * branches can only give identical codes, so keeping one of them
+ * bounds the work on long words full of ambiguous letters.
+ */
+func appendBranch(branches []dmsBranch, b dmsBranch) []dmsBranch {
+	for _, have := range branches {
+		if have == b {
+			return branches
+		}
+	}
+	return append(branches, b)
+}
+
+/**
+ * Tests whether list contains s.
+ */
+func containsString(list []string, s string) bool {
+	for _, have := range list {
+		if have == s {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Upper cases word, strips its accents except from the letters
+ * with rules of their own, folds 'Ț' onto 'Ţ', and drops white
+ * space.
+ */
+func dmsClean(word string) []rune {
+	src := []rune(word)
+	folded, _ := dmsNormalization.Normalize(make([]rune, 0, len(src)), make([]int, 0, len(src)), src)
+
+	in := folded[:0]
+	for _, r := range folded {
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == 'ß':
+			r = 'S'
+		}
+		if r = unicode.ToUpper(r); r == 'Ț' {
+			r = 'Ţ'
+		}
+		in = append(in, r)
+	}
+	return in
+}
+
+/** Accent folding applied before encoding. */
+var dmsNormalization = Normalization{
+	Compose:         true,
+	ExpandLigatures: true,
+	StripAccents:    true,
+	KeepAccented:    "ĄĘŢȚ",
+}
+
+/**
+ * A row of the coding chart: a letter group and its codes at the
+ * start of the word, before a vowel, and anywhere else. A code of
+ * "" means the group is not coded.
+ */
+type dmsRule struct {
+	pattern     []rune
+	atStart     []string
+	beforeVowel []string
+	other       []string
+}
+
+/**
+ * Returns the codes of the rule for the group at in[at:].
+ */
+func (r *dmsRule) replacements(in []rune, at int, atStart bool) []string {
+	if atStart {
+		return r.atStart
+	}
+	if next := at + len(r.pattern); next < len(in) && dmsIsVowel(in[next]) {
+		return r.beforeVowel
+	}
+	return r.other
+}
+
+/**
+ * Tests whether c is a vowel for the "before a vowel" column.
+ */
+func dmsIsVowel(c rune) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+	return false
+}
+
+/**
+ * Returns the rule with the longest letter group that in
+ * starts with, or nil if there is none.
+ */
+func dmsMatch(in []rune) *dmsRule {
+	for _, r := range dmsRules[in[0]] {
+		if len(r.pattern) <= len(in) && runesHavePrefix(in, r.pattern) {
+			return r
+		}
+	}
+	return nil
+}
+
+/**
+ * Tests whether in starts with prefix.
+ */
+func runesHavePrefix(in, prefix []rune) bool {
+	for i, r := range prefix {
+		if in[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+/** The coding chart by first letter, longest group first. */
+var dmsRules = make(map[rune][]*dmsRule)
+
+func init() {
+	for _, row := range dmsChart {
+		r := &dmsRule{
+			pattern:     []rune(row[0]),
+			atStart:     strings.Split(row[1], "|"),
+			beforeVowel: strings.Split(row[2], "|"),
+			other:       strings.Split(row[3], "|"),
+		}
+		dmsRules[r.pattern[0]] = append(dmsRules[r.pattern[0]], r)
+	}
+
+	for _, rules := range dmsRules {
+		sort.SliceStable(rules, func(i, j int) bool {
+			return len(rules[i].pattern) > len(rules[j].pattern)
+		})
+	}
+}
+
+/**
+ * The Daitch-Mokotoff coding chart: letter group, code at the start
+ * of the word, code before a vowel, code anywhere else. Alternative
+ * codes of ambiguous groups are separated by '|'.
+ */
+var dmsChart = [][4]string{
+	{"AI", "0", "1", ""}, {"AJ", "0", "1", ""}, {"AY", "0", "1", ""},
+	{"AU", "0", "7", ""},
+	{"A", "0", "", ""},
+	{"Ą", "", "", "6|"},
+	{"B", "7", "7", "7"},
+	{"CHS", "5", "54", "54"},
+	{"CH", "5|4", "5|4", "5|4"},
+	{"CK", "5|45", "5|45", "5|45"},
+	{"CSZ", "4", "4", "4"}, {"CZS", "4", "4", "4"}, {"CS", "4", "4", "4"}, {"CZ", "4", "4", "4"},
+	{"C", "5|4", "5|4", "5|4"},
+	{"DRZ", "4", "4", "4"}, {"DRS", "4", "4", "4"},
+	{"DSH", "4", "4", "4"}, {"DSZ", "4", "4", "4"}, {"DS", "4", "4", "4"},
+	{"DZH", "4", "4", "4"}, {"DZS", "4", "4", "4"}, {"DZ", "4", "4", "4"},
+	{"DT", "3", "3", "3"}, {"D", "3", "3", "3"},
+	{"EI", "0", "1", ""}, {"EJ", "0", "1", ""}, {"EY", "0", "1", ""},
+	{"EU", "1", "1", ""},
+	{"E", "0", "", ""},
+	{"Ę", "", "", "6|"},
+	{"FB", "7", "7", "7"}, {"F", "7", "7", "7"},
+	{"G", "5", "5", "5"},
+	{"H", "5", "5", ""},
+	{"IA", "1", "", ""}, {"IE", "1", "", ""}, {"IO", "1", "", ""}, {"IU", "1", "", ""},
+	{"I", "0", "", ""},
+	{"J", "1|4", "|4", "|4"},
+	{"KS", "5", "54", "54"}, {"KH", "5", "5", "5"}, {"K", "5", "5", "5"},
+	{"L", "8", "8", "8"},
+	{"MN", "66", "66", "66"}, {"M", "6", "6", "6"},
+	{"NM", "66", "66", "66"}, {"N", "6", "6", "6"},
+	{"OI", "0", "1", ""}, {"OJ", "0", "1", ""}, {"OY", "0", "1", ""},
+	{"O", "0", "", ""},
+	{"PF", "7", "7", "7"}, {"PH", "7", "7", "7"}, {"P", "7", "7", "7"},
+	{"Q", "5", "5", "5"},
+	{"RZ", "94|4", "94|4", "94|4"}, {"RS", "94|4", "94|4", "94|4"}, {"R", "9", "9", "9"},
+	{"SCHTSCH", "2", "4", "4"}, {"SCHTSH", "2", "4", "4"}, {"SCHTCH", "2", "4", "4"},
+	{"SHTCH", "2", "4", "4"}, {"SHCH", "2", "4", "4"}, {"SHTSH", "2", "4", "4"},
+	{"SCHT", "2", "43", "43"}, {"SCHD", "2", "43", "43"}, {"SCH", "4", "4", "4"},
+	{"SHT", "2", "43", "43"}, {"SHD", "2", "43", "43"}, {"SH", "4", "4", "4"},
+	{"STCH", "2", "4", "4"}, {"STSCH", "2", "4", "4"}, {"SC", "2", "4", "4"},
+	{"STRZ", "2", "4", "4"}, {"STRS", "2", "4", "4"}, {"STSH", "2", "4", "4"},
+	{"ST", "2", "43", "43"},
+	{"SZCZ", "2", "4", "4"}, {"SZCS", "2", "4", "4"},
+	{"SZT", "2", "43", "43"}, {"SZD", "2", "43", "43"}, {"SD", "2", "43", "43"},
+	{"SZ", "4", "4", "4"}, {"S", "4", "4", "4"},
+	{"TCH", "4", "4", "4"}, {"TTCH", "4", "4", "4"}, {"TTSCH", "4", "4", "4"},
+	{"TH", "3", "3", "3"},
+	{"TRZ", "4", "4", "4"}, {"TRS", "4", "4", "4"},
+	{"TSCH", "4", "4", "4"}, {"TSH", "4", "4", "4"},
+	{"TS", "4", "4", "4"}, {"TTS", "4", "4", "4"}, {"TTSZ", "4", "4", "4"}, {"TC", "4", "4", "4"},
+	{"TZ", "4", "4", "4"}, {"TTZ", "4", "4", "4"}, {"TZS", "4", "4", "4"}, {"TSZ", "4", "4", "4"},
+	{"T", "3", "3", "3"},
+	{"Ţ", "3|4", "3|4", "3|4"},
+	{"UI", "0", "1", ""}, {"UJ", "0", "1", ""}, {"UY", "0", "1", ""},
+	{"UE", "0", "", ""}, {"U", "0", "", ""},
+	{"V", "7", "7", "7"},
+	{"W", "7", "7", "7"},
+	{"X", "5", "54", "54"},
+	{"Y", "1", "", ""},
+	{"ZHDZH", "2", "4", "4"}, {"ZDZH", "2", "4", "4"}, {"ZDZ", "2", "4", "4"},
+	{"ZHD", "2", "43", "43"}, {"ZD", "2", "43", "43"},
+	{"ZSCH", "4", "4", "4"}, {"ZSH", "4", "4", "4"}, {"ZH", "4", "4", "4"}, {"ZS", "4", "4", "4"},
+	{"Z", "4", "4", "4"},
+}
@@ -0,0 +1,99 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+)
+
+var daitchMokotoffTests = []struct {
+	word  string
+	codes []string
+}{
+	{"AUERBACH", []string{"097500", "097400"}},
+	{"OHRBACH", []string{"097500", "097400"}},
+	{"LIPSHITZ", []string{"874400"}},
+	{"Peters", []string{"739400", "734000"}},
+	{"Moskowitz", []string{"645740"}},
+	{"Jackson", []string{"154600", "145460", "454600", "445460"}},
+	{"Rosochowaciec", []string{"945755", "945754", "945745", "945744", "944755", "944754", "944745", "944744"}},
+	{"Schwarzenegger", []string{"479465", "474659"}},
+	{"Smith", []string{"463000"}},
+
+	// letters with rules of their own
+	{"Ąa", []string{"000000"}},
+	{"Ţ", []string{"300000", "400000"}},
+	{"ţ", []string{"300000", "400000"}},
+	{"Ț", []string{"300000", "400000"}},
+	{"ț", []string{"300000", "400000"}},
+	{"T\u0327", []string{"300000", "400000"}}, // combining cedilla
+	{"T\u0326", []string{"300000", "400000"}}, // combining comma below
+	{"Țepeș", []string{"374000", "474000"}},
+	{"Tepes", []string{"374000"}},
+
+	// accents, case and white space
+	{"Müller", []string{"689000"}},
+	{"van der Berg", []string{"763979"}},
+	{"", nil},
+	{"  ", nil},
+}
+
+func TestDaitchMokotoff(t *testing.T) {
+	d := NewDaitchMokotoff()
+	for _, test := range daitchMokotoffTests {
+		codes := d.Codes(test.word)
+		if !reflect.DeepEqual(codes, test.codes) {
+			t.Errorf("Codes(%q) = %v, want %v", test.word, codes, test.codes)
+		}
+
+		var wantPrimary, wantAlternate string
+		if len(test.codes) > 0 {
+			wantPrimary = test.codes[0]
+		}
+		if len(test.codes) > 1 {
+			wantAlternate = test.codes[1]
+		}
+		if primary, alternate := d.Encode(test.word); primary != wantPrimary || alternate != wantAlternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q", test.word, primary, alternate, wantPrimary, wantAlternate)
+		}
+	}
+}
+
+func TestDaitchMokotoffMatch(t *testing.T) {
+	d := NewDaitchMokotoff()
+	for _, test := range []struct {
+		a, b  string
+		match bool
+	}{
+		{"Moskowitz", "Moskovitz", true},
+		{"Jackson", "Yakson", true},
+		{"Țepeș", "Ţepeş", true},
+		{"Țepeș", "Tsepesh", true},
+		{"Peters", "Petersen", false},
+		{"Smith", "Jones", false},
+	} {
+		if match := d.Match(test.a, test.b); match != test.match {
+			t.Errorf("Match(%q, %q) = %t, want %t", test.a, test.b, match, test.match)
+		}
+	}
+}
+
+func TestCompareDaitchMokotoff(t *testing.T) {
+	m, d := New(), NewDaitchMokotoff()
+	for _, test := range []struct {
+		a, b string
+		want Agreement
+	}{
+		{"Smith", "Schmidt", Agreement{M3: true, DaitchMokotoff: true}},
+		{"Moskowitz", "Moskovitz", Agreement{M3: false, DaitchMokotoff: true}},
+		{"Jackson", "Yakson", Agreement{M3: false, DaitchMokotoff: true}},
+		{"Smith", "Jones", Agreement{M3: false, DaitchMokotoff: false}},
+	} {
+		got := m.CompareDaitchMokotoff(d, test.a, test.b)
+		if got != test.want {
+			t.Errorf("CompareDaitchMokotoff(%q, %q) = %+v, want %+v", test.a, test.b, got, test.want)
+		}
+		if agree := got.Agree(); agree != (test.want.M3 == test.want.DaitchMokotoff) {
+			t.Errorf("CompareDaitchMokotoff(%q, %q).Agree() = %t", test.a, test.b, agree)
+		}
+	}
+}
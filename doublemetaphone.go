@@ -0,0 +1,832 @@
+package metaphone3
+
+import (
+	"strings"
+	"unicode"
+)
+
+/** Default length of Double Metaphone keys. */
+const DEFAULT_DOUBLE_METAPHONE_LENGTH = 4
+
+/**
+ * DoubleMetaphone encodes words with Double Metaphone, the
+ * predecessor of Metaphone 3 by the same author. Its keys are
+ * not comparable with Metaphone 3 keys; it is provided to read
+ * and migrate indexes keyed with it.
+ *
+ * A DoubleMetaphone is safe for concurrent use once configured.
+ */
+type DoubleMetaphone struct {
+	/** Length keys are truncated to. */
+	keyLength int
+}
+
+/**
+ * Constructor, default: DEFAULT_DOUBLE_METAPHONE_LENGTH long keys.
+ */
+func NewDoubleMetaphone() *DoubleMetaphone {
+	return &DoubleMetaphone{keyLength: DEFAULT_DOUBLE_METAPHONE_LENGTH}
+}
+
+/**
+ * Sets the length keys are truncated to; values below 1
+ * are taken as 1.
+ */
+func (d *DoubleMetaphone) SetKeyLength(keyLength int) {
+	if keyLength < 1 {
+		keyLength = 1
+	}
+	d.keyLength = keyLength
+}
+
+/** Returns the length keys are truncated to. */
+func (d *DoubleMetaphone) KeyLength() int { return d.keyLength }
+
+/**
+ * Encodes word to its primary and alternate Double Metaphone
+ * keys. The alternate key is empty if it is the same as the
+ * primary key.
+ */
+func (d *DoubleMetaphone) Encode(word string) (primary, alternate string) {
+	s := dmState{
+		value:     []rune(strings.Map(unicode.ToUpper, strings.TrimSpace(word))),
+		keyLength: d.keyLength,
+	}
+	if len(s.value) == 0 {
+		return "", ""
+	}
+
+	s.run()
+
+	primary, alternate = string(s.primary), string(s.alternate)
+	if primary == alternate {
+		alternate = ""
+	}
+	return primary, alternate
+}
+
+/**
+ * Working state of a single Double Metaphone encoding.
+ */
+type dmState struct {
+	/** The upper cased word, one rune per letter. */
+	value []rune
+
+	/** The keys so far, each at most keyLength long. */
+	primary, alternate []byte
+
+	/** Length the keys are cut to. */
+	keyLength int
+
+	/** Whether the word looks slavic or germanic: it has a 'W',
+	 * a 'K', "CZ" or "WITZ". Set by run. */
+	slavoGermanic bool
+}
+
+/**
+ * Encodes s.value into s.primary and s.alternate, one letter
+ * or group of letters at a time, until the end of the word or
+ * until both keys are keyLength long. Each handler appends to
+ * the keys and returns the index of the next letter to encode.
+ */
+func (s *dmState) run() {
+	s.slavoGermanic = s.containsAny("W", "K", "CZ", "WITZ")
+
+	index := 0
+	if s.at(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		index = 1
+	}
+
+	for !s.complete() && index < len(s.value) {
+		switch s.value[index] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				s.add("A")
+			}
+			index++
+		case 'B':
+			s.add("P")
+			index = s.skipDouble(index, 'B')
+		case 'Ç':
+			s.add("S")
+			index++
+		case 'C':
+			index = s.handleC(index)
+		case 'D':
+			index = s.handleD(index)
+		case 'F':
+			s.add("F")
+			index = s.skipDouble(index, 'F')
+		case 'G':
+			index = s.handleG(index)
+		case 'H':
+			index = s.handleH(index)
+		case 'J':
+			index = s.handleJ(index)
+		case 'K':
+			s.add("K")
+			index = s.skipDouble(index, 'K')
+		case 'L':
+			index = s.handleL(index)
+		case 'M':
+			s.add("M")
+			if s.conditionM0(index) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			s.add("N")
+			index = s.skipDouble(index, 'N')
+		case 'Ñ':
+			s.add("N")
+			index++
+		case 'P':
+			index = s.handleP(index)
+		case 'Q':
+			s.add("K")
+			index = s.skipDouble(index, 'Q')
+		case 'R':
+			index = s.handleR(index)
+		case 'S':
+			index = s.handleS(index)
+		case 'T':
+			index = s.handleT(index)
+		case 'V':
+			s.add("F")
+			index = s.skipDouble(index, 'V')
+		case 'W':
+			index = s.handleW(index)
+		case 'X':
+			index = s.handleX(index)
+		case 'Z':
+			index = s.handleZ(index)
+		default:
+			index++
+		}
+	}
+}
+
+/** Tests whether both keys are full. */
+func (s *dmState) complete() bool {
+	return len(s.primary) >= s.keyLength && len(s.alternate) >= s.keyLength
+}
+
+/** Appends key to both keys. */
+func (s *dmState) add(key string) { s.add2(key, key) }
+
+/** Appends main to the primary and alt to the alternate key. */
+func (s *dmState) add2(main, alt string) {
+	s.primary = appendLimited(s.primary, main, s.keyLength)
+	s.alternate = appendLimited(s.alternate, alt, s.keyLength)
+}
+
+/** Appends key to the primary key only. */
+func (s *dmState) addPrimary(key string) {
+	s.primary = appendLimited(s.primary, key, s.keyLength)
+}
+
+/** Appends key to the alternate key only. */
+func (s *dmState) addAlternate(key string) {
+	s.alternate = appendLimited(s.alternate, key, s.keyLength)
+}
+
+/** Appends as much of add to key as fits in limit. */
+func appendLimited(key []byte, add string, limit int) []byte {
+	if room := limit - len(key); len(add) > room {
+		if room <= 0 {
+			return key
+		}
+		add = add[:room]
+	}
+	return append(key, add...)
+}
+
+/** Returns the letter at index, or 0 if out of range. */
+func (s *dmState) charAt(index int) rune {
+	if index < 0 || index >= len(s.value) {
+		return 0
+	}
+	return s.value[index]
+}
+
+/**
+ * Tests whether one of strs, each length letters long,
+ * is found at start.
+ */
+func (s *dmState) at(start, length int, strs ...string) bool {
+	if start < 0 || start+length > len(s.value) {
+		return false
+	}
+
+	target := s.value[start : start+length]
+	for _, str := range strs {
+		if runesEqual(target, str) {
+			return true
+		}
+	}
+	return false
+}
+
+/** Tests whether any of strs appears anywhere in the word. */
+func (s *dmState) containsAny(strs ...string) bool {
+	word := string(s.value)
+	for _, str := range strs {
+		if strings.Contains(word, str) {
+			return true
+		}
+	}
+	return false
+}
+
+/** Skips a doubled letter. */
+func (s *dmState) skipDouble(index int, letter rune) int {
+	if s.charAt(index+1) == letter {
+		return index + 2
+	}
+	return index + 1
+}
+
+/** Tests whether c is a vowel; 'Y' counts as one. */
+func dmIsVowel(c rune) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		return true
+	}
+	return false
+}
+
+/**
+ * Encodes the 'C' at index: 'K' in germanic "-ACH-", 'S' before a
+ * front vowel and in "CAESAR", 'X' for "CIA", both for "CZ" and
+ * italian "CIO", and "CH" and "CC" by their own handlers.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleC(index int) int {
+	switch {
+	case s.conditionC0(index):
+		// various germanic
+		s.add("K")
+		index += 2
+	case index == 0 && s.at(index, 6, "CAESAR"):
+		s.add("S")
+		index += 2
+	case s.at(index, 2, "CH"):
+		index = s.handleCH(index)
+	case s.at(index, 2, "CZ") && !s.at(index-2, 4, "WICZ"):
+		// "czerny"
+		s.add2("S", "X")
+		index += 2
+	case s.at(index+1, 3, "CIA"):
+		// "focaccia"
+		s.add("X")
+		index += 3
+	case s.at(index, 2, "CC") && !(index == 1 && s.charAt(0) == 'M'):
+		// double "C", but not "McClellan"
+		return s.handleCC(index)
+	case s.at(index, 2, "CK", "CG", "CQ"):
+		s.add("K")
+		index += 2
+	case s.at(index, 2, "CI", "CE", "CY"):
+		// italian vs. english
+		if s.at(index, 3, "CIO", "CIE", "CIA") {
+			s.add2("S", "X")
+		} else {
+			s.add("S")
+		}
+		index += 2
+	default:
+		s.add("K")
+		if s.at(index+1, 2, " C", " Q", " G") {
+			// "Mac Caffrey", "Mac Gregor"
+			index += 3
+		} else if s.at(index+1, 1, "C", "K", "Q") && !s.at(index+1, 2, "CE", "CI") {
+			index += 2
+		} else {
+			index++
+		}
+	}
+	return index
+}
+
+/**
+ * Encodes the "CC" at index: "KS" in "accident" and "succeed",
+ * 'X' in italian names such as "bacci", 'K' otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleCC(index int) int {
+	if s.at(index+2, 1, "I", "E", "H") && !s.at(index+2, 2, "HU") {
+		// "bellocchio" but not "bacchus"
+		if (index == 1 && s.charAt(index-1) == 'A') || s.at(index-1, 5, "UCCEE", "UCCES") {
+			// "accident", "accede", "succeed"
+			s.add("KS")
+		} else {
+			// "bacci", "bertucci", other italian
+			s.add("X")
+		}
+		return index + 3
+	}
+
+	// Pierce's rule
+	s.add("K")
+	return index + 2
+}
+
+/**
+ * Encodes the "CH" at index: 'K' in greek and germanic words and
+ * after "MC", 'X' at the start of other words, and 'X' with an
+ * alternate 'K' elsewhere.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleCH(index int) int {
+	switch {
+	case index > 0 && s.at(index, 4, "CHAE"):
+		// Michael
+		s.add2("K", "X")
+	case s.conditionCH0(index), s.conditionCH1(index):
+		// greek roots, germanic
+		s.add("K")
+	case index > 0:
+		if s.at(0, 2, "MC") {
+			s.add("K")
+		} else {
+			s.add2("X", "K")
+		}
+	default:
+		s.add("X")
+	}
+	return index + 2
+}
+
+/**
+ * Encodes the 'D' at index: 'J' for "DGE", "DGI" and "DGY",
+ * "TK" for other "DG", 'T' otherwise, skipping a 'T' or 'D'
+ * after it.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleD(index int) int {
+	switch {
+	case s.at(index, 2, "DG"):
+		if s.at(index+2, 1, "I", "E", "Y") {
+			// "edge"
+			s.add("J")
+			return index + 3
+		}
+		// "edgar"
+		s.add("TK")
+		return index + 2
+	case s.at(index, 2, "DT", "DD"):
+		s.add("T")
+		return index + 2
+	}
+	s.add("T")
+	return index + 1
+}
+
+/**
+ * Encodes the 'G' at index: "GH" by handleGH, "GN" as 'N' or
+ * "KN", italian "GLI" as "KL" or 'L', 'J' (or 'K') before a front
+ * vowel, and 'K' otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleG(index int) int {
+	next := s.charAt(index + 1)
+	switch {
+	case next == 'H':
+		return s.handleGH(index)
+	case next == 'N':
+		if index == 1 && dmIsVowel(s.charAt(0)) && !s.slavoGermanic {
+			s.add2("KN", "N")
+		} else if !s.at(index+2, 2, "EY") && s.charAt(index+1) != 'Y' && !s.slavoGermanic {
+			s.add2("N", "KN")
+		} else {
+			s.add("KN")
+		}
+		return index + 2
+	case s.at(index+1, 2, "LI") && !s.slavoGermanic:
+		// "tagliaro"
+		s.add2("KL", "L")
+		return index + 2
+	case index == 0 && (next == 'Y' || s.at(index+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		// -ges-, -gep-, -gel- at beginning
+		s.add2("K", "J")
+		return index + 2
+	case (s.at(index+1, 2, "ER") || next == 'Y') && !s.at(0, 6, "DANGER", "RANGER", "MANGER") && !s.at(index-1, 1, "E", "I") && !s.at(index-1, 3, "RGY", "OGY"):
+		// -ger-, -gy-
+		s.add2("K", "J")
+		return index + 2
+	case s.at(index+1, 1, "E", "I", "Y") || s.at(index-1, 4, "AGGI", "OGGI"):
+		// italian "biaggi"
+		if s.at(0, 4, "VAN ", "VON ") || s.at(0, 3, "SCH") || s.at(index+1, 2, "ET") {
+			// obvious germanic
+			s.add("K")
+		} else if s.at(index+1, 3, "IER") {
+			s.add("J")
+		} else {
+			s.add2("J", "K")
+		}
+		return index + 2
+	case next == 'G':
+		s.add("K")
+		return index + 2
+	}
+	s.add("K")
+	return index + 1
+}
+
+/**
+ * Encodes the "GH" at index: 'K' after a consonant and at the
+ * start, 'J' in "GHI-", 'F' in "laugh" and "tough", and nothing
+ * where it is silent, e.g. in "hugh" and "night".
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleGH(index int) int {
+	switch {
+	case index > 0 && !dmIsVowel(s.charAt(index-1)):
+		s.add("K")
+	case index == 0:
+		// "ghislane", "ghiradelli"
+		if s.charAt(index+2) == 'I' {
+			s.add("J")
+		} else {
+			s.add("K")
+		}
+	case (index > 1 && s.at(index-2, 1, "B", "H", "D")) || (index > 2 && s.at(index-3, 1, "B", "H", "D")) || (index > 3 && s.at(index-4, 1, "B", "H")):
+		// Parker's rule (with some further refinements) - "hugh"
+	default:
+		if index > 2 && s.charAt(index-1) == 'U' && s.at(index-3, 1, "C", "G", "L", "R", "T") {
+			// "laugh", "McLaughlin", "cough", "gough", "rough", "tough"
+			s.add("F")
+		} else if index > 0 && s.charAt(index-1) != 'I' {
+			s.add("K")
+		}
+	}
+	return index + 2
+}
+
+/**
+ * Encodes the 'H' at index, which is kept only at the start of
+ * the word or after a vowel, and only before a vowel.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleH(index int) int {
+	// only keep if first & before vowel or between 2 vowels
+	if (index == 0 || dmIsVowel(s.charAt(index-1))) && dmIsVowel(s.charAt(index+1)) {
+		s.add("H")
+		return index + 2
+	}
+	// also takes care of "HH"
+	return index + 1
+}
+
+/**
+ * Encodes the 'J' at index: 'H' in spanish "JOSE" and "SAN ...",
+ * 'J' with an alternate 'A' at the start, 'H' as an alternate
+ * between vowels, and nothing before some consonants.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleJ(index int) int {
+	if s.at(index, 4, "JOSE") || s.at(0, 4, "SAN ") {
+		// obvious spanish, "jose", "San Jacinto"
+		if (index == 0 && s.charAt(index+4) == ' ') || len(s.value) == 4 || s.at(0, 4, "SAN ") {
+			s.add("H")
+		} else {
+			s.add2("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		// Yankelovich/Jankelowicz
+		s.add2("J", "A")
+	case dmIsVowel(s.charAt(index-1)) && !s.slavoGermanic && (s.charAt(index+1) == 'A' || s.charAt(index+1) == 'O'):
+		// spanish pron. of e.g. "bajador"
+		s.add2("J", "H")
+	case index == len(s.value)-1:
+		s.add2("J", "")
+	case !s.at(index+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !s.at(index-1, 1, "S", "K", "L"):
+		s.add("J")
+	}
+
+	return s.skipDouble(index, 'J')
+}
+
+/**
+ * Encodes the 'L' at index. A spanish "LL", e.g. "cabrillo", is
+ * left out of the alternate key.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleL(index int) int {
+	if s.charAt(index+1) == 'L' {
+		if s.conditionL0(index) {
+			// spanish e.g. "cabrillo", "gallegos"
+			s.addPrimary("L")
+		} else {
+			s.add("L")
+		}
+		return index + 2
+	}
+	s.add("L")
+	return index + 1
+}
+
+/**
+ * Encodes the 'P' at index: 'F' for "PH", 'P' otherwise, skipping
+ * a 'P' or 'B' after it.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleP(index int) int {
+	if s.charAt(index+1) == 'H' {
+		s.add("F")
+		return index + 2
+	}
+
+	s.add("P")
+	// also account for "campbell", "raspberry"
+	if s.at(index+1, 1, "P", "B") {
+		return index + 2
+	}
+	return index + 1
+}
+
+/**
+ * Encodes the 'R' at index. A final 'R' after "IE" is silent in
+ * the primary key, as in french "rogier".
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleR(index int) int {
+	if index == len(s.value)-1 && !s.slavoGermanic && s.at(index-2, 2, "IE") && !s.at(index-4, 2, "ME", "MA") {
+		// french e.g. "rogier", but exclude "hochmeier"
+		s.addAlternate("R")
+	} else {
+		s.add("R")
+	}
+	return s.skipDouble(index, 'R')
+}
+
+/**
+ * Encodes the 'S' at index: silent in "isle", 'X' for "SH" and
+ * "SUGAR", 'S' with an alternate 'X' in italian "SIO" and before
+ * 'M', 'N', 'L', 'W' or 'Z', "SC" by handleSC, and 'S' otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleS(index int) int {
+	switch {
+	case s.at(index-1, 3, "ISL", "YSL"):
+		// special cases "island", "isle", "carlisle", "carlysle"
+		return index + 1
+	case index == 0 && s.at(index, 5, "SUGAR"):
+		// special case "sugar-"
+		s.add2("X", "S")
+		return index + 1
+	case s.at(index, 2, "SH"):
+		if s.at(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// germanic
+			s.add("S")
+		} else {
+			s.add("X")
+		}
+		return index + 2
+	case s.at(index, 3, "SIO", "SIA") || s.at(index, 4, "SIAN"):
+		// italian & armenian
+		if s.slavoGermanic {
+			s.add("S")
+		} else {
+			s.add2("S", "X")
+		}
+		return index + 3
+	case (index == 0 && s.at(index+1, 1, "M", "N", "L", "W")) || s.at(index+1, 1, "Z"):
+		// german & anglicisations, e.g. "smith" match "schmidt",
+		// "snider" match "schneider"; also, -sz- in slavic
+		// language although in hungarian it is pronounced "s"
+		s.add2("S", "X")
+		if s.at(index+1, 1, "Z") {
+			return index + 2
+		}
+		return index + 1
+	case s.at(index, 2, "SC"):
+		return s.handleSC(index)
+	}
+
+	if index == len(s.value)-1 && s.at(index-2, 2, "AI", "OI") {
+		// french e.g. "resnais", "artois"
+		s.addAlternate("S")
+	} else {
+		s.add("S")
+	}
+	if s.at(index+1, 1, "S", "Z") {
+		return index + 2
+	}
+	return index + 1
+}
+
+/**
+ * Encodes the "SC" at index: "SCH" as 'X', or "SK" in dutch words
+ * such as "school", 'S' before a front vowel, and "SK" otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleSC(index int) int {
+	switch {
+	case s.charAt(index+2) == 'H':
+		// Schlesinger's rule
+		if s.at(index+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+			// dutch origin, e.g. "school", "schooner"
+			if s.at(index+3, 2, "ER", "EN") {
+				// "schermerhorn", "schenker"
+				s.add2("X", "SK")
+			} else {
+				s.add("SK")
+			}
+		} else if index == 0 && !dmIsVowel(s.charAt(3)) && s.charAt(3) != 'W' {
+			s.add2("X", "S")
+		} else {
+			s.add("X")
+		}
+	case s.at(index+2, 1, "I", "E", "Y"):
+		s.add("S")
+	default:
+		s.add("SK")
+	}
+	return index + 3
+}
+
+/**
+ * Encodes the 'T' at index: 'X' for "TION", "TIA" and "TCH", '0'
+ * (th) with an alternate 'T' for "TH", and 'T' otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleT(index int) int {
+	switch {
+	case s.at(index, 4, "TION"), s.at(index, 3, "TIA", "TCH"):
+		s.add("X")
+		return index + 3
+	case s.at(index, 2, "TH") || s.at(index, 3, "TTH"):
+		if s.at(index+2, 2, "OM", "AM") || s.at(0, 4, "VAN ", "VON ") || s.at(0, 3, "SCH") {
+			// special case "thomas", "thames" or germanic
+			s.add("T")
+		} else {
+			s.add2("0", "T")
+		}
+		return index + 2
+	}
+
+	s.add("T")
+	if s.at(index+1, 1, "T", "D") {
+		return index + 2
+	}
+	return index + 1
+}
+
+/**
+ * Encodes the 'W' at index: 'R' for "WR", 'A' (or 'F') at the
+ * start before a vowel, an alternate 'F' where it may be a 'V',
+ * as in "Arnow", "TS" for polish "WICZ", and nothing otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleW(index int) int {
+	switch {
+	case s.at(index, 2, "WR"):
+		// can also be in middle of word
+		s.add("R")
+		return index + 2
+	case index == 0 && (dmIsVowel(s.charAt(index+1)) || s.at(index, 2, "WH")):
+		if dmIsVowel(s.charAt(index + 1)) {
+			// Wasserman should match Vasserman
+			s.add2("A", "F")
+		} else {
+			// need Uomo to match Womo
+			s.add("A")
+		}
+		return index + 1
+	case (index == len(s.value)-1 && dmIsVowel(s.charAt(index-1))) || s.at(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || s.at(0, 3, "SCH"):
+		// Arnow should match Arnoff
+		s.addAlternate("F")
+		return index + 1
+	case s.at(index, 4, "WICZ", "WITZ"):
+		// polish e.g. "filipowicz"
+		s.add2("TS", "FX")
+		return index + 4
+	}
+	return index + 1
+}
+
+/**
+ * Encodes the 'X' at index: 'S' at the start, silent at the end
+ * of french words such as "breaux", "KS" otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleX(index int) int {
+	if index == 0 {
+		s.add("S")
+		return index + 1
+	}
+
+	if !(index == len(s.value)-1 && (s.at(index-3, 3, "IAU", "EAU") || s.at(index-2, 2, "AU", "OU"))) {
+		// french e.g. breaux
+		s.add("KS")
+	}
+	if s.at(index+1, 1, "C", "X") {
+		return index + 2
+	}
+	return index + 1
+}
+
+/**
+ * Encodes the 'Z' at index: 'J' for pinyin "ZH", 'S' with an
+ * alternate "TS" in italian and slavic words, 'S' otherwise.
+ *
+ * @return the index of the next letter to encode
+ */
+func (s *dmState) handleZ(index int) int {
+	if s.charAt(index+1) == 'H' {
+		// chinese pinyin e.g. "zhao"
+		s.add("J")
+		return index + 2
+	}
+
+	if s.at(index+1, 2, "ZO", "ZI", "ZA") || (s.slavoGermanic && index > 0 && s.charAt(index-1) != 'T') {
+		s.add2("S", "TS")
+	} else {
+		s.add("S")
+	}
+	return s.skipDouble(index, 'Z')
+}
+
+/**
+ * Tests whether the 'C' at index is a germanic 'K', as in
+ * "bacher" and "macher", or starts "CHIA".
+ */
+func (s *dmState) conditionC0(index int) bool {
+	if s.at(index, 4, "CHIA") {
+		return true
+	}
+	if index <= 1 || dmIsVowel(s.charAt(index-2)) || !s.at(index-1, 3, "ACH") {
+		return false
+	}
+	c := s.charAt(index + 2)
+	return (c != 'I' && c != 'E') || s.at(index-2, 6, "BACHER", "MACHER")
+}
+
+/**
+ * Tests whether the "CH" starting the word is greek, e.g.
+ * "character", "chorus", "chemistry", but not "chore".
+ */
+func (s *dmState) conditionCH0(index int) bool {
+	if index != 0 {
+		return false
+	}
+	if !s.at(index+1, 5, "HARAC", "HARIS") && !s.at(index+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+	return !s.at(0, 5, "CHORE")
+}
+
+/**
+ * Tests whether the "CH" at index is a 'K' in a germanic name or
+ * in words such as "orchestra" and "architect", or before a
+ * consonant.
+ */
+func (s *dmState) conditionCH1(index int) bool {
+	return s.at(0, 4, "VAN ", "VON ") || s.at(0, 3, "SCH") || s.at(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") || s.at(index+2, 1, "T", "S") ||
+		((s.at(index-1, 1, "A", "O", "U", "E") || index == 0) && (s.at(index+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || index+1 == len(s.value)-1))
+}
+
+/**
+ * Tests whether the "LL" at index is spanish, e.g. "cabrillo",
+ * "gallegos".
+ */
+func (s *dmState) conditionL0(index int) bool {
+	n := len(s.value)
+	if index == n-3 && s.at(index-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+	return (s.at(n-2, 2, "AS", "OS") || s.at(n-1, 1, "A", "O")) && s.at(index-1, 4, "ALLE")
+}
+
+/**
+ * Tests whether the letter after the 'M' at index is skipped:
+ * a second 'M', or the silent 'B' of "dumb" and "dumber".
+ */
+func (s *dmState) conditionM0(index int) bool {
+	if s.charAt(index+1) == 'M' {
+		return true
+	}
+	return s.at(index-1, 3, "UMB") && (index+1 == len(s.value)-1 || s.at(index+2, 2, "ER"))
+}
@@ -0,0 +1,106 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+/** Double Metaphone keys at the default length of 4. */
+var doubleMetaphoneTests = []struct {
+	word, primary, alternate string
+}{
+	// initial letter groups
+	{"Knight", "NT", ""},
+	{"Wright", "RT", ""},
+	{"Gnome", "NM", ""},
+	{"Xavier", "SF", "SFR"},
+	{"Island", "ALNT", ""},
+
+	// C
+	{"Caesar", "SSR", ""},
+	{"Chianti", "KNT", ""},
+	{"Michael", "MKL", "MXL"},
+	{"Chemistry", "KMST", ""},
+	{"Orchestra", "ARKS", ""},
+	{"Czerny", "SRN", "XRN"},
+	{"Focaccia", "FKX", ""},
+	{"Accident", "AKST", ""},
+	{"Succeed", "SKST", ""},
+	{"Bacchus", "PKS", ""},
+	{"Bellocchio", "PLX", ""},
+	{"McClellan", "MKLL", ""},
+	{"Mac Caffrey", "MKFR", ""},
+
+	// D, G, H, J
+	{"Edge", "AJ", ""},
+	{"Edgar", "ATKR", ""},
+	{"Tagliaro", "TKLR", "TLR"},
+	{"Biaggi", "PJ", "PK"},
+	{"Ghislane", "JLN", ""},
+	{"Laugh", "LF", ""},
+	{"Hugh", "H", ""},
+	{"Jose", "HS", ""},
+	{"Jankelowicz", "JNKL", "ANKL"},
+
+	// L, M, P
+	{"Gallegos", "KLKS", "KKS"},
+	{"Cabrillo", "KPRL", "KPR"},
+	{"Thumb", "0M", "TM"},
+	{"Dumb", "TM", ""},
+	{"Campbell", "KMPL", ""},
+
+	// S
+	{"Smith", "SM0", "XMT"},
+	{"Schmidt", "XMT", "SMT"},
+	{"School", "SKL", ""},
+	{"Schenker", "XNKR", "SKNK"},
+	{"Sugar", "XKR", "SKR"},
+	{"Resnais", "RSN", "RSNS"},
+
+	// T, W, X, Z
+	{"Thomas", "TMS", ""},
+	{"Arnow", "ARN", "ARNF"},
+	{"Wasserman", "ASRM", "FSRM"},
+	{"Womo", "AM", "FM"},
+	{"Filipowicz", "FLPT", "FLPF"},
+	{"Breaux", "PR", ""},
+	{"Zhao", "J", ""},
+
+	// letters outside A to Z, case and spacing
+	{"Ñandú", "NNT", ""},
+	{"Français", "FRNS", ""},
+	{"  smith  ", "SM0", "XMT"},
+	{"", "", ""},
+	{"   ", "", ""},
+}
+
+func TestDoubleMetaphone(t *testing.T) {
+	d := NewDoubleMetaphone()
+	for _, test := range doubleMetaphoneTests {
+		if primary, alternate := d.Encode(test.word); primary != test.primary || alternate != test.alternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q", test.word, primary, alternate, test.primary, test.alternate)
+		}
+	}
+}
+
+func TestDoubleMetaphoneKeyLength(t *testing.T) {
+	d := NewDoubleMetaphone()
+	if n := d.KeyLength(); n != DEFAULT_DOUBLE_METAPHONE_LENGTH {
+		t.Errorf("KeyLength() = %d, want %d", n, DEFAULT_DOUBLE_METAPHONE_LENGTH)
+	}
+
+	for _, test := range []struct {
+		keyLength          int
+		primary, alternate string
+	}{
+		{0, "J", "A"},
+		{1, "J", "A"},
+		{2, "JN", "AN"},
+		{8, "JNKLTS", "ANKLFX"},
+		{20, "JNKLTS", "ANKLFX"},
+	} {
+		d.SetKeyLength(test.keyLength)
+		if primary, alternate := d.Encode("Jankelowicz"); primary != test.primary || alternate != test.alternate {
+			t.Errorf("SetKeyLength(%d): Encode(Jankelowicz) = %q, %q, want %q, %q", test.keyLength, primary, alternate, test.primary, test.alternate)
+		}
+	}
+}
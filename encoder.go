@@ -0,0 +1,131 @@
+package metaphone3
+
+import (
+	"fmt"
+	"sort"
+)
+
+/**
+ * PhoneticEncoder is implemented by every phonetic algorithm in
+ * this package, so that callers can switch between them by
+ * configuration and compare their keys on the same input.
+ */
+type PhoneticEncoder interface {
+	/**
+	 * Encodes word to its primary key and, for algorithms and
+	 * words that have one, an alternate key (empty otherwise).
+	 */
+	Encode(word string) (primary, alternate string)
+}
+
+var (
+	_ PhoneticEncoder = (*M3)(nil)
+	_ PhoneticEncoder = (*DoubleMetaphone)(nil)
+	_ PhoneticEncoder = (*Soundex)(nil)
+	_ PhoneticEncoder = (*RefinedSoundex)(nil)
+	_ PhoneticEncoder = (*DaitchMokotoff)(nil)
+	_ PhoneticEncoder = (*NYSIIS)(nil)
+	_ PhoneticEncoder = (*Caverphone2)(nil)
+	_ PhoneticEncoder = (*ColognePhonetic)(nil)
+)
+
+/**
+ * Constructors of the encoders, by the name NewEncoder
+ * knows them by.
+ */
+var encoders = map[string]func() PhoneticEncoder{
+	"metaphone3":        func() PhoneticEncoder { return New() },
+	"doublemetaphone":   func() PhoneticEncoder { return NewDoubleMetaphone() },
+	"soundex":           func() PhoneticEncoder { return NewSoundex() },
+	"simplifiedsoundex": func() PhoneticEncoder { return NewSimplifiedSoundex() },
+	"refinedsoundex":    func() PhoneticEncoder { return NewRefinedSoundex() },
+	"daitchmokotoff":    func() PhoneticEncoder { return NewDaitchMokotoff() },
+	"nysiis":            func() PhoneticEncoder { return NewNYSIIS() },
+	"caverphone2":       func() PhoneticEncoder { return NewCaverphone2() },
+	"colognephonetic":   func() PhoneticEncoder { return NewColognePhonetic() },
+}
+
+/**
+ * Creates an encoder with its default settings from its name,
+ * as listed by EncoderNames, e.g. "metaphone3".
+ *
+ * @return the encoder, or an error if name is not known
+ */
+func NewEncoder(name string) (PhoneticEncoder, error) {
+	newEncoder, ok := encoders[name]
+	if !ok {
+		return nil, fmt.Errorf("metaphone3: unknown encoder %q", name)
+	}
+	return newEncoder(), nil
+}
+
+/**
+ * Returns the names NewEncoder accepts, sorted.
+ */
+func EncoderNames() []string {
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/**
+ * EncoderKey is the key of a word under one encoder.
+ */
+type EncoderKey struct {
+	/** Name of the encoder, as NewEncoder knows it. */
+	Encoder string `json:"encoder"`
+
+	/** Primary key. */
+	Primary string `json:"primary"`
+
+	/** Alternate key, or "" if there is none. */
+	Alternate string `json:"alternate,omitempty"`
+}
+
+/**
+ * Comparison holds the keys of one word under several encoders.
+ */
+type Comparison struct {
+	/** The word as given. */
+	Word string `json:"word"`
+
+	/** Keys of the word, in the order the encoders were named. */
+	Keys []EncoderKey `json:"keys"`
+}
+
+/**
+ * Encodes every word with each of the named encoders, each with its
+ * default settings, so that their keys can be compared on the same
+ * word list. Without names, every encoder of EncoderNames is used.
+ *
+ * @return one Comparison per word, in order, or an error if a name
+ * is not known
+ */
+func CompareEncoders(words []string, names ...string) ([]Comparison, error) {
+	if len(names) == 0 {
+		names = EncoderNames()
+	}
+
+	encs := make([]PhoneticEncoder, len(names))
+	for i, name := range names {
+		enc, err := NewEncoder(name)
+		if err != nil {
+			return nil, err
+		}
+		encs[i] = enc
+	}
+
+	comparisons := make([]Comparison, len(words))
+	for i, word := range words {
+		keys := make([]EncoderKey, len(encs))
+		for j, enc := range encs {
+			primary, alternate := enc.Encode(word)
+			keys[j] = EncoderKey{Encoder: names[j], Primary: primary, Alternate: alternate}
+		}
+		comparisons[i] = Comparison{Word: word, Keys: keys}
+	}
+	return comparisons, nil
+}
@@ -0,0 +1,91 @@
+package metaphone3
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNewEncoder(t *testing.T) {
+	names := EncoderNames()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("EncoderNames() = %v, not sorted", names)
+	}
+
+	for _, name := range names {
+		enc, err := NewEncoder(name)
+		if err != nil {
+			t.Errorf("NewEncoder(%q): %v", name, err)
+			continue
+		}
+		if primary, _ := enc.Encode("Smith"); primary == "" {
+			t.Errorf("%s: Encode(Smith) is empty", name)
+		}
+	}
+
+	if _, err := NewEncoder("metaphone4"); err == nil || !strings.HasPrefix(err.Error(), "metaphone3: ") {
+		t.Errorf("NewEncoder(metaphone4) error = %v, want a metaphone3 error", err)
+	}
+}
+
+func TestCompareEncoders(t *testing.T) {
+	got, err := CompareEncoders([]string{"Smith", "Schmidt"}, "soundex", "metaphone3", "daitchmokotoff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Comparison{
+		{Word: "Smith", Keys: []EncoderKey{
+			{Encoder: "soundex", Primary: "S530"},
+			{Encoder: "metaphone3", Primary: "SM0", Alternate: "XMT"},
+			{Encoder: "daitchmokotoff", Primary: "463000"},
+		}},
+		{Word: "Schmidt", Keys: []EncoderKey{
+			{Encoder: "soundex", Primary: "S530"},
+			{Encoder: "metaphone3", Primary: "XMT"},
+			{Encoder: "daitchmokotoff", Primary: "463000"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareEncoders() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompareEncodersDefaults(t *testing.T) {
+	got, err := CompareEncoders([]string{"Müller"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := EncoderNames()
+	if len(got) != 1 || len(got[0].Keys) != len(names) {
+		t.Fatalf("CompareEncoders() = %+v, want one word with %d keys", got, len(names))
+	}
+	for i, key := range got[0].Keys {
+		if key.Encoder != names[i] {
+			t.Errorf("key %d from %q, want %q", i, key.Encoder, names[i])
+		}
+		enc, _ := NewEncoder(key.Encoder)
+		if primary, alternate := enc.Encode("Müller"); key.Primary != primary || key.Alternate != alternate {
+			t.Errorf("%s: key %q, %q, want %q, %q", key.Encoder, key.Primary, key.Alternate, primary, alternate)
+		}
+	}
+}
+
+func TestCompareEncodersUnknown(t *testing.T) {
+	got, err := CompareEncoders([]string{"Smith"}, "soundex", "nosuch")
+	if err == nil || !strings.Contains(err.Error(), `"nosuch"`) {
+		t.Errorf("CompareEncoders(nosuch) = %v, %v, want an error naming it", got, err)
+	}
+	if got != nil {
+		t.Errorf("CompareEncoders(nosuch) = %v, want nil", got)
+	}
+}
+
+func TestCompareEncodersNoWords(t *testing.T) {
+	got, err := CompareEncoders(nil, "soundex")
+	if err != nil || len(got) != 0 {
+		t.Errorf("CompareEncoders(nil) = %v, %v, want no comparisons", got, err)
+	}
+}
@@ -0,0 +1,489 @@
+package metaphone3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * ExceptionSet is a named list of words, or beginnings of words,
+ * that one of the rules treats specially, e.g. the names starting
+ * with 'W' that get an alternate 'V'. The sets the rules use by
+ * default are the package level variables below; an encoder can
+ * be given extended or replacement sets with WithExceptions.
+ *
+ * Entries are upper cased as they are added. A set must not be
+ * modified while an encoder using it is encoding, so extend a
+ * Clone of a default set rather than the set itself.
+ */
+type ExceptionSet struct {
+	name string
+
+	/** byLength[n] holds the entries n runes long. */
+	byLength [][]string
+
+	/** The entries compiled into a trie, nodes[0] being the
+	 * root, so that a word is matched against all of them in
+	 * one pass over its letters. */
+	nodes []trieNode
+
+	/** Sum of the hashes of the entries, which does not depend
+	 * on the order they were added in. See Exceptions.digest. */
+	sum uint64
+}
+
+/**
+ * A node of the trie of an ExceptionSet: the letters that
+ * may follow, and whether an entry ends here.
+ */
+type trieNode struct {
+	next []trieEdge
+	end  bool
+}
+
+/** A letter leading from one trie node to another. */
+type trieEdge struct {
+	letter rune
+	node   int32
+}
+
+/**
+ * Returns the node reached from node by letter,
+ * or -1 if there is none.
+ */
+func (e *ExceptionSet) child(node int32, letter rune) int32 {
+	for _, edge := range e.nodes[node].next {
+		if edge.letter == letter {
+			return edge.node
+		}
+	}
+	return -1
+}
+
+/** Adds an upper cased entry to the trie. */
+func (e *ExceptionSet) insert(word string) {
+	if len(e.nodes) == 0 {
+		e.nodes = append(e.nodes, trieNode{})
+	}
+
+	node := int32(0)
+	for _, letter := range word {
+		next := e.child(node, letter)
+		if next < 0 {
+			next = int32(len(e.nodes))
+			e.nodes = append(e.nodes, trieNode{})
+			e.nodes[node].next = append(e.nodes[node].next, trieEdge{letter, next})
+		}
+		node = next
+	}
+	e.nodes[node].end = true
+}
+
+/**
+ * Builds an exception set from Go values.
+ */
+func NewExceptionSet(name string, words ...string) *ExceptionSet {
+	e := &ExceptionSet{name: name}
+	e.Add(words...)
+	return e
+}
+
+/**
+ * Reads an exception set from r: one entry per line, with
+ * blank lines and lines starting with '#' ignored.
+ *
+ * @return the set, or the error that stopped reading
+ */
+func ReadExceptionSet(name string, r io.Reader) (*ExceptionSet, error) {
+	e := NewExceptionSet(name)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e.Add(line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("metaphone3: reading exception set %s: %w", name, err)
+	}
+
+	return e, nil
+}
+
+/**
+ * Reads an exception set from the text file at path, in the
+ * format of ReadExceptionSet.
+ */
+func LoadExceptionSet(name, path string) (*ExceptionSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("metaphone3: loading exception set %s: %w", name, err)
+	}
+	defer f.Close()
+
+	return ReadExceptionSet(name, f)
+}
+
+/** Returns the name the set was made with. */
+func (e *ExceptionSet) Name() string { return e.name }
+
+/**
+ * Adds words to the set. Empty words and words already in
+ * the set are ignored.
+ */
+func (e *ExceptionSet) Add(words ...string) {
+	for _, word := range words {
+		word = strings.Map(unicode.ToUpper, word)
+		n := utf8.RuneCountInString(word)
+		if n == 0 || e.Contains(word) {
+			continue
+		}
+
+		for len(e.byLength) <= n {
+			e.byLength = append(e.byLength, nil)
+		}
+		e.byLength[n] = append(e.byLength[n], word)
+		e.insert(word)
+		e.sum += fnvAdd(fnvOffset, word)
+	}
+}
+
+/**
+ * Tests whether word is an entry of the set.
+ */
+func (e *ExceptionSet) Contains(word string) bool {
+	word = strings.Map(unicode.ToUpper, word)
+	n := utf8.RuneCountInString(word)
+	if n >= len(e.byLength) {
+		return false
+	}
+
+	for _, entry := range e.byLength[n] {
+		if entry == word {
+			return true
+		}
+	}
+	return false
+}
+
+/** Returns the number of entries. */
+func (e *ExceptionSet) Len() int {
+	n := 0
+	for _, entries := range e.byLength {
+		n += len(entries)
+	}
+	return n
+}
+
+/**
+ * Returns the entries, shortest first.
+ */
+func (e *ExceptionSet) Words() []string {
+	words := make([]string, 0, e.Len())
+	for _, entries := range e.byLength {
+		words = append(words, entries...)
+	}
+	return words
+}
+
+/**
+ * Returns a copy of the set that can be changed without
+ * affecting e.
+ */
+func (e *ExceptionSet) Clone() *ExceptionSet {
+	return NewExceptionSet(e.name, e.Words()...)
+}
+
+/**
+ * Tests whether the word being encoded begins with
+ * one of the entries of e.
+ */
+func (m *metaph) startsWith(e *ExceptionSet) bool {
+	if len(e.nodes) == 0 {
+		return false
+	}
+
+	node := int32(0)
+	for _, letter := range m.inWord[:m.length] {
+		if node = e.child(node, letter); node < 0 {
+			return false
+		}
+		if e.nodes[node].end {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Tests whether the word being encoded is one
+ * of the entries of e.
+ */
+func (m *metaph) wordIn(e *ExceptionSet) bool {
+	if len(e.nodes) == 0 {
+		return false
+	}
+
+	node := int32(0)
+	for _, letter := range m.inWord[:m.length] {
+		if node = e.child(node, letter); node < 0 {
+			return false
+		}
+	}
+	return e.nodes[node].end
+}
+
+/**
+ * Exceptions holds the exception sets an encoder uses. A nil
+ * set stands for the package default of the same name.
+ */
+type Exceptions struct {
+	/** See the package variable GermanicOrSlavicW. */
+	GermanicOrSlavicW *ExceptionSet
+
+	/** See the package variable JAltY. */
+	JAltY *ExceptionSet
+
+	/** See the package variable SWAltSV. */
+	SWAltSV *ExceptionSet
+
+	/** See the package variable SWAltXV. */
+	SWAltXV *ExceptionSet
+
+	/** See the package variable EPronouncedAtEnd. */
+	EPronouncedAtEnd *ExceptionSet
+
+	/** See the package variable EPronouncedBeforeS. */
+	EPronouncedBeforeS *ExceptionSet
+}
+
+/**
+ * Returns the exception sets used by New().
+ */
+func DefaultExceptions() Exceptions {
+	return Exceptions{
+		GermanicOrSlavicW:  GermanicOrSlavicW,
+		JAltY:              JAltY,
+		SWAltSV:            SWAltSV,
+		SWAltXV:            SWAltXV,
+		EPronouncedAtEnd:   EPronouncedAtEnd,
+		EPronouncedBeforeS: EPronouncedBeforeS,
+	}
+}
+
+/**
+ * Returns e with its nil sets replaced by the defaults.
+ */
+func (e Exceptions) withDefaults() Exceptions {
+	d := DefaultExceptions()
+	for _, s := range []struct{ set, def **ExceptionSet }{
+		{&e.GermanicOrSlavicW, &d.GermanicOrSlavicW},
+		{&e.JAltY, &d.JAltY},
+		{&e.SWAltSV, &d.SWAltSV},
+		{&e.SWAltXV, &d.SWAltXV},
+		{&e.EPronouncedAtEnd, &d.EPronouncedAtEnd},
+		{&e.EPronouncedBeforeS, &d.EPronouncedBeforeS},
+	} {
+		if *s.set == nil {
+			*s.set = *s.def
+		}
+	}
+	return e
+}
+
+/**
+ * Returns a hash of the entries of every set, nil sets
+ * standing for the defaults.
+ */
+func (e Exceptions) digest() uint64 {
+	e = e.withDefaults()
+	h := fnvOffset
+	for _, s := range []*ExceptionSet{
+		e.GermanicOrSlavicW, e.JAltY, e.SWAltSV,
+		e.SWAltXV, e.EPronouncedAtEnd, e.EPronouncedBeforeS,
+	} {
+		h = fnvAddUint64(h, s.sum)
+	}
+	return h
+}
+
+/**
+ * Digest of the default sets as they are built, before
+ * anything could have been added to them.
+ */
+var defaultExceptionsDigest = DefaultExceptions().digest()
+
+/**
+ * Beginnings of names of germanic or slavic origin starting with
+ * 'W', which get an alternate encoding of 'V', e.g. "Wojcik".
+ */
+var GermanicOrSlavicW = NewExceptionSet("GermanicOrSlavicW",
+	"WEE", "WIX", "WAX", "WOLF", "WEIS", "WAHL", "WALZ", "WEIL", "WERT",
+	"WINE", "WILK", "WALT", "WOLL", "WADA", "WULF", "WEHR", "WURM",
+	"WYSE", "WENZ", "WIRT", "WOLK", "WEIN", "WYSS", "WASS", "WANN",
+	"WINT", "WINK", "WILE", "WIKE", "WIER", "WELK", "WISE", "WIRTH",
+	"WIESE", "WITTE", "WENTZ", "WOLFF", "WENDT", "WERTZ", "WILKE",
+	"WALTZ", "WEISE", "WOOLF", "WERTH", "WEESE", "WURTH", "WINES",
+	"WARGO", "WIMER", "WISER", "WAGER", "WILLE", "WILDS", "WAGAR",
+	"WERTS", "WITTY", "WIENS", "WIEBE", "WIRTZ", "WYMER", "WULFF",
+	"WIBLE", "WINER", "WIEST", "WALKO", "WALLA", "WEBRE", "WEYER",
+	"WYBLE", "WOMAC", "WILTZ", "WURST", "WOLAK", "WELKE", "WEDEL",
+	"WEIST", "WYGAN", "WUEST", "WEISZ", "WALCK", "WEITZ", "WYDRA",
+	"WANDA", "WILMA", "WEBER", "WETZEL", "WEINER", "WENZEL", "WESTER",
+	"WALLEN", "WENGER", "WALLIN", "WEILER", "WIMMER", "WEIMER", "WYRICK",
+	"WEGNER", "WINNER", "WESSEL", "WILKIE", "WEIGEL", "WOJCIK", "WENDEL",
+	"WITTER", "WIENER", "WEISER", "WEXLER", "WACKER", "WISNER", "WITMER",
+	"WINKLE", "WELTER", "WIDMER", "WITTEN", "WINDLE", "WASHER", "WOLTER",
+	"WILKEY", "WIDNER", "WARMAN", "WEYANT", "WEIBEL", "WANNER", "WILKEN",
+	"WILTSE", "WARNKE", "WALSER", "WEIKEL", "WESNER", "WITZEL", "WROBEL",
+	"WAGNON", "WINANS", "WENNER", "WOLKEN", "WILNER", "WYSONG", "WYCOFF",
+	"WUNDER", "WINKEL", "WIDMAN", "WELSCH", "WEHNER", "WEIGLE", "WETTER",
+	"WUNSCH", "WHITTY", "WAXMAN", "WILKER", "WILHAM", "WITTIG", "WITMAN",
+	"WESTRA", "WEHRLE", "WASSER", "WILLER", "WEGMAN", "WARFEL", "WYNTER",
+	"WERNER", "WAGNER", "WISSER", "WISEMAN", "WINKLER", "WILHELM",
+	"WELLMAN", "WAMPLER", "WACHTER", "WALTHER", "WYCKOFF", "WEIDNER",
+	"WOZNIAK", "WEILAND", "WILFONG", "WIEGAND", "WILCHER", "WIELAND",
+	"WILDMAN", "WALDMAN", "WORTMAN", "WYSOCKI", "WEIDMAN", "WITTMAN",
+	"WIDENER", "WOLFSON", "WENDELL", "WEITZEL", "WILLMAN", "WALDRUP",
+	"WALTMAN", "WALCZAK", "WEIGAND", "WESSELS", "WIDEMAN", "WOLTERS",
+	"WIREMAN", "WILHOIT", "WEGENER", "WOTRING", "WINGERT", "WIESNER",
+	"WAYMIRE", "WHETZEL", "WENTZEL", "WINEGAR", "WESTMAN", "WYNKOOP",
+	"WALLICK", "WURSTER", "WINBUSH", "WILBERT", "WALLACH", "WEISSER",
+	"WEISNER", "WINDERS", "WILLMON", "WILLEMS", "WIERSMA", "WACHTEL",
+	"WARNICK", "WEIDLER", "WALTRIP", "WHETSEL", "WHELESS", "WELCHER",
+	"WALBORN", "WILLSEY", "WEINMAN", "WAGAMAN", "WOMMACK", "WINGLER",
+	"WINKLES", "WIEDMAN", "WHITNER", "WOLFRAM", "WARLICK", "WEEDMAN",
+	"WHISMAN", "WINLAND", "WEESNER", "WARTHEN", "WETZLER", "WENDLER",
+	"WALLNER", "WOLBERT", "WITTMER", "WISHART", "WILLIAM", "WESTPHAL",
+	"WICKLUND", "WEISSMAN", "WESTLUND", "WOLFGANG", "WILLHITE", "WEISBERG",
+	"WALRAVEN", "WOLFGRAM", "WILHOITE", "WECHSLER", "WENDLING", "WESTBERG",
+	"WENDLAND", "WININGER", "WHISNANT", "WESTRICK", "WESTLING", "WESTBURY",
+	"WEITZMAN", "WEHMEYER", "WEINMANN", "WISNESKI", "WHELCHEL", "WEISHAAR",
+	"WAGGENER", "WALDROUP", "WESTHOFF", "WIEDEMAN", "WASINGER", "WINBORNE",
+	"WHISENANT", "WEINSTEIN", "WESTERMAN", "WASSERMAN", "WITKOWSKI",
+	"WEINTRAUB", "WINKELMAN", "WINKFIELD", "WANAMAKER", "WIECZOREK",
+	"WIECHMANN", "WOJTOWICZ", "WALKOWIAK", "WEINSTOCK", "WILLEFORD",
+	"WARKENTIN", "WEISINGER", "WINKLEMAN", "WILHEMINA", "WISNIEWSKI",
+	"WUNDERLICH", "WHISENHUNT", "WEINBERGER", "WROBLEWSKI", "WAGUESPACK",
+	"WEISGERBER", "WESTERVELT", "WESTERLUND", "WASILEWSKI", "WILDERMUTH",
+	"WESTENDORF", "WESOLOWSKI", "WEINGARTEN", "WINEBARGER", "WESTERBERG",
+	"WANNAMAKER", "WEISSINGER", "WALDSCHMIDT", "WEINGARTNER", "WINEBRENNER",
+	"WOLFENBARGER", "WOJCIECHOWSKI",
+)
+
+/**
+ * Beginnings of names starting with 'J' that get an alternate
+ * encoding as a vowel, to match e.g. 'Ian', 'Yana' and 'Yusef'.
+ */
+var JAltY = NewExceptionSet("JAltY",
+	// "JAKOB" is listed in the reference among the six letter
+	// names, where it can never match, so it is left out here
+	"JAN", "JON", "JIN", "JEN", "JUHL", "JULY", "JOEL", "JOHN", "JOSH",
+	"JUDE", "JUNE", "JONI", "JULI", "JENA", "JUNG", "JINA", "JANA",
+	"JENI", "JANN", "JONA", "JENE", "JULE", "JANI", "JONG", "JEAN",
+	"JONE", "JARA", "JUST", "JOST", "JAHN", "JACO", "JANG", "JOANN",
+	"JANEY", "JANAE", "JOANA", "JUTTA", "JULEE", "JANAY", "JANEE",
+	"JETTA", "JOHNA", "JOANE", "JAYNA", "JANES", "JONAS", "JONIE",
+	"JUSTA", "JUNIE", "JUNKO", "JENAE", "JULIO", "JINNY", "JOHNS",
+	"JACOB", "JETER", "JAFFE", "JESKE", "JANKE", "JAGER", "JANIK",
+	"JANDA", "JOSHI", "JULES", "JANTZ", "JEANS", "JUDAH", "JANUS",
+	"JENNY", "JENEE", "JONAH", "JOSUE", "JOSEF", "JULIE", "JULIA",
+	"JANIE", "JANIS", "JENNA", "JANNA", "JEANA", "JENNI", "JEANE",
+	"JONNA", "JORDAN", "JORDON", "JOSEPH", "JOSHUA", "JOSIAH", "JOSPEH",
+	"JUDSON", "JULIAN", "JULIUS", "JUNIOR", "JUDITH", "JOESPH", "JOHNIE",
+	"JOANNE", "JEANNE", "JOANNA", "JOSEFA", "JULIET", "JANNIE", "JANELL",
+	"JASMIN", "JANINE", "JOHNNY", "JEANIE", "JEANNA", "JOHNNA", "JOELLE",
+	"JOVITA", "JONNIE", "JANEEN", "JANINA", "JOANIE", "JAZMIN", "JANENE",
+	"JONELL", "JENELL", "JANETT", "JANETH", "JENINE", "JOELLA", "JOEANN",
+	"JOHANA", "JENICE", "JANNET", "JANISE", "JULENE", "JANEAN", "JAIMEE",
+	"JOETTE", "JANYCE", "JENEVA", "JACOBS", "JENSEN", "JANSEN", "JAEGER",
+	"JACOBY", "JENSON", "JARMAN", "JOSLIN", "JESSEN", "JAHNKE", "JACOBO",
+	"JULIEN", "JEPSON", "JANSON", "JACOBI", "JARBOE", "JOHSON", "JANZEN",
+	"JETTON", "JUNKER", "JONSON", "JAROSZ", "JENNER", "JAGGER", "JEPSEN",
+	"JORDEN", "JANNEY", "JUHASZ", "JERGEN", "JOHNSON", "JOHNNIE", "JASMINE",
+	"JEANNIE", "JOHANNA", "JANELLE", "JANETTE", "JULIANA", "JUSTINA",
+	"JOSETTE", "JOELLEN", "JENELLE", "JULIETA", "JULIANN", "JULISSA",
+	"JENETTE", "JANETTA", "JOSELYN", "JONELLE", "JESENIA", "JANESSA",
+	"JAZMINE", "JEANENE", "JOANNIE", "JADWIGA", "JOLANDA", "JULIANE",
+	"JANUARY", "JEANICE", "JANELLA", "JEANETT", "JENNINE", "JOHANNE",
+	"JOHNSIE", "JANIECE", "JENNELL", "JAMISON", "JANSSEN", "JOHNSEN",
+	"JARDINE", "JAGGERS", "JURGENS", "JOURDAN", "JULIANO", "JOSEPHS",
+	"JHONSON", "JOZWIAK", "JANICKI", "JELINEK", "JANSSON", "JOACHIM",
+	"JACOBUS", "JENNING", "JANTZEN", "JOSEFINA", "JEANNINE", "JULIANNE",
+	"JULIANNA", "JONATHAN", "JONATHON", "JEANETTE", "JANNETTE", "JEANETTA",
+	"JOHNETTA", "JENNEFER", "JULIENNE", "JOSPHINE", "JEANELLE", "JOHNETTE",
+	"JULIEANN", "JOSEFINE", "JULIETTA", "JOHNSTON", "JACOBSON", "JACOBSEN",
+	"JOHANSEN", "JOHANSON", "JAWORSKI", "JENNETTE", "JELLISON", "JOHANNES",
+	"JASINSKI", "JUERGENS", "JARNAGIN", "JEREMIAH", "JEPPESEN", "JARNIGAN",
+	"JANOUSEK", "JOHNATHAN", "JOHNATHON", "JORGENSEN", "JEANMARIE",
+	"JOSEPHINA", "JEANNETTE", "JOSEPHINE", "JEANNETTA", "JORGENSON",
+	"JANKOWSKI", "JOHNSTONE", "JABLONSKI", "JOSEPHSON", "JOHANNSEN",
+	"JURGENSEN", "JIMMERSON", "JOHANSSON", "JAKUBOWSKI",
+)
+
+/**
+ * Beginnings of names of swedish, dutch or slavic origin starting
+ * with "SW", which get an alternate encoding of "SV".
+ */
+var SWAltSV = NewExceptionSet("SWAltSV",
+	"SWANSON", "SWENSON", "SWINSON", "SWENSEN", "SWOBODA", "SWIDERSKI",
+	"SWARTHOUT", "SWEARENGIN",
+)
+
+/**
+ * Beginnings of names of german origin starting with "SW", which
+ * get an alternate encoding of "XV" to match "schw-".
+ */
+var SWAltXV = NewExceptionSet("SWAltXV",
+	"SWART", "SWARTZ", "SWARTS", "SWIGER", "SWITZER", "SWANGER", "SWIGERT",
+	"SWIGART", "SWIHART", "SWEITZER", "SWATZELL", "SWINDLER", "SWINEHART",
+	"SWEARINGEN",
+)
+
+/**
+ * Whole words ending in an 'E' that is pronounced, e.g. "Jose",
+ * "cafe", "karaoke".
+ */
+var EPronouncedAtEnd = NewExceptionSet("EPronouncedAtEnd",
+	"ACME", "NIKE", "CAFE", "RENE", "LUPE", "JOSE", "ESME", "LETHE",
+	"CADRE", "TILDE", "SIGNE", "POSSE", "LATTE", "ANIME", "DOLCE",
+	"CROCE", "ADOBE", "OUTRE", "JESSE", "JAIME", "JAFFE", "BENGE",
+	"RUNGE", "CHILE", "DESME", "CONDE", "URIBE", "LIBRE", "ANDRE",
+	"HECATE", "PSYCHE", "DAPHNE", "PENSKE", "CLICHE", "RECIPE", "TAMALE",
+	"SESAME", "SIMILE", "FINALE", "KARATE", "RENATE", "SHANTE", "OBERLE",
+	"COYOTE", "KRESGE", "STONGE", "STANGE", "SWAYZE", "FUENTE", "SALOME",
+	"URRIBE", "ECHIDNE", "ARIADNE", "MEINEKE", "PORSCHE", "ANEMONE",
+	"EPITOME", "SYNCOPE", "SOUFFLE", "ATTACHE", "MACHETE", "KARAOKE",
+	"BUKKAKE", "VICENTE", "ELLERBE", "VERSACE", "PENELOPE", "CALLIOPE",
+	"CHIPOTLE", "ANTIGONE", "KAMIKAZE", "EURIDICE", "YOSEMITE", "FERRANTE",
+	"HYPERBOLE", "GUACAMOLE", "XANTHIPPE", "SYNECDOCHE",
+)
+
+/**
+ * Beginnings of words ending in "-ES" where the 'E' is
+ * pronounced, mostly hispanic names, e.g. "Robles", "Flores".
+ */
+var EPronouncedBeforeS = NewExceptionSet("EPronouncedBeforeS",
+	"INES", "LOPES", "ESTES", "GOMES", "NUNES", "ALVES", "ICKES", "INNES",
+	"PERES", "WAGES", "NEVES", "BENES", "DONES", "CORTES", "CHAVES",
+	"VALDES", "ROBLES", "TORRES", "FLORES", "BORGES", "NIEVES", "MONTES",
+	"SOARES", "VALLES", "GEDDES", "ANDRES", "VIAJES", "CALLES", "FONTES",
+	"HERMES", "ACEVES", "BATRES", "MATHES", "DELORES", "MORALES", "DOLORES",
+	"ANGELES", "ROSALES", "MIRELES", "LINARES", "PERALES", "PAREDES",
+	"BRIONES", "SANCHES", "CAZARES", "REVELES", "ESTEVES", "ALVARES",
+	"MATTHES", "SOLARES", "CASARES", "CACERES", "STURGES", "RAMIRES",
+	"FUNCHES", "BENITES", "FUENTES", "PUENTES", "TABARES", "HENTGES",
+	"VALORES", "GONZALES", "MERCEDES", "FAGUNDES", "JOHANNES", "GONSALES",
+	"BERMUDES", "CESPEDES", "BETANCES", "TERRONES", "DIOGENES", "CORRALES",
+	"CABRALES", "MARTINES", "GRAJALES", "CERVANTES", "FERNANDES", "GONCALVES",
+	"BENEVIDES", "CIFUENTES", "SIFUENTES", "SERVANTES", "HERNANDES",
+	"BENAVIDES", "ARCHIMEDES", "CARRIZALES", "MAGALLANES",
+)
@@ -0,0 +1,154 @@
+package metaphone3
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+)
+
+/** The default exception sets, by name. */
+func defaultExceptionSets() []*ExceptionSet {
+	e := DefaultExceptions()
+	return []*ExceptionSet{
+		e.GermanicOrSlavicW, e.JAltY, e.SWAltSV,
+		e.SWAltXV, e.EPronouncedAtEnd, e.EPronouncedBeforeS,
+	}
+}
+
+/**
+ * startsWith as it was before the trie: each list of
+ * entries of one length compared in turn with stringAt.
+ */
+func (m *metaph) startsWithLinear(e *ExceptionSet) bool {
+	for n, entries := range e.byLength {
+		if len(entries) > 0 && m.stringAt(0, n, entries...) {
+			return true
+		}
+	}
+	return false
+}
+
+/** wordIn as it was before the trie. */
+func (m *metaph) wordInLinear(e *ExceptionSet) bool {
+	if m.length >= len(e.byLength) {
+		return false
+	}
+	return m.stringAt(0, m.length, e.byLength[m.length]...)
+}
+
+/**
+ * Returns the words of the golden corpus, and every entry of the
+ * default exception sets with a letter cut off, a letter changed,
+ * and letters added, so that near misses are tried as well.
+ */
+func exceptionTestWords(t testing.TB) []string {
+	f, err := os.Open(goldenCorpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var words []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if text := sc.Text(); text != "" && !strings.HasPrefix(text, "#") {
+			words = append(words, strings.SplitN(text, "\t", 2)[0])
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range defaultExceptionSets() {
+		for _, w := range e.Words() {
+			words = append(words, w, strings.ToLower(w), w[:len(w)-1], w[:len(w)-1]+"Q", w+"S", w+"SON")
+		}
+	}
+	return append(words, "", "J", "JAKOB", "JAKOBS", "JAKOBSEN")
+}
+
+/** Loads word into m as the word being encoded. */
+func (m *metaph) loadWord(word string) {
+	m.setWord(word)
+	m.length = len(m.inWord)
+}
+
+func TestExceptionTrieMatchesLinear(t *testing.T) {
+	m := New().getMetaph()
+	defer metaphPool.Put(m)
+
+	words := exceptionTestWords(t)
+	for _, e := range defaultExceptionSets() {
+		starts, in := 0, 0
+		for _, word := range words {
+			m.loadWord(word)
+
+			if got, want := m.startsWith(e), m.startsWithLinear(e); got != want {
+				t.Errorf("%s: startsWith(%q) = %t, linear %t", e.Name(), word, got, want)
+			} else if got {
+				starts++
+			}
+
+			if got, want := m.wordIn(e), m.wordInLinear(e); got != want {
+				t.Errorf("%s: wordIn(%q) = %t, linear %t", e.Name(), word, got, want)
+			} else if got {
+				in++
+			}
+		}
+		t.Logf("%s: %d of %d words start with an entry, %d are entries", e.Name(), starts, len(words), in)
+	}
+}
+
+/**
+ * The reference lists "JAKOB" among the six letter names of
+ * names_Beginning_With_J_That_Get_Alt_Y, where stringAt(0, 6, ...)
+ * compares six letters with five and so never matches it. JAltY
+ * leaves it out, which changes no key; this pins that down.
+ */
+func TestJAltYDropsJAKOB(t *testing.T) {
+	if JAltY.Contains("JAKOB") {
+		t.Error("JAltY contains JAKOB")
+	}
+
+	m := New().getMetaph()
+	defer metaphPool.Put(m)
+
+	for _, word := range []string{"JAKOB", "JAKOBS", "JAKOBSEN", "JAKOBI"} {
+		m.loadWord(word)
+		if m.stringAt(0, 6, "JAKOB") {
+			t.Errorf("stringAt(0, 6, JAKOB) matches %q", word)
+		}
+		if m.startsWith(JAltY) {
+			t.Errorf("%q starts with an entry of JAltY", word)
+		}
+	}
+}
+
+func BenchmarkExceptionSets(b *testing.B) {
+	m := New().getMetaph()
+	defer metaphPool.Put(m)
+
+	words := exceptionTestWords(b)
+	sets := defaultExceptionSets()
+
+	for _, impl := range []struct {
+		name             string
+		startsWith, isIn func(*metaph, *ExceptionSet) bool
+	}{
+		{"trie", (*metaph).startsWith, (*metaph).wordIn},
+		{"linear", (*metaph).startsWithLinear, (*metaph).wordInLinear},
+	} {
+		b.Run(impl.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, word := range words {
+					m.loadWord(word)
+					for _, e := range sets {
+						impl.startsWith(m, e)
+						impl.isIn(m, e)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package metaphone3
+
+import (
+	"math"
+)
+
+/** Default limit on the number of keys EncodeAll returns. */
+const DEFAULT_MAX_KEYS = 16
+
+/**
+ * What one call to metaphAdd appended: the main and the
+ * alternate encoding of a piece of the word. Where they
+ * differ, the word can be pronounced either way.
+ */
+type choice struct {
+	main, alt string
+}
+
+/**
+ * Encodes in and returns every distinct key the word can have.
+ *
+ * Encode settles each ambiguous spelling of a word one way for the
+ * primary key and the other way for the secondary key. EncodeAll
+ * instead combines the choices made at each ambiguous spot freely,
+ * so that a name like "Wojcik" (Germanic or Slavic 'W', Polish or
+ * English "CI", ...) gives all its plausible keys rather than two.
+ *
+ * The keys are truncated to Config.KeyLength. The first key is
+ * the primary key of Encode, followed by its secondary key, if
+ * any. The other combinations follow in the order they branch
+ * off: each ambiguous spot, from the start of the word on, adds
+ * the keys that take its alternate after those that do not. At
+ * most Config.MaxKeys keys are returned and the rest are dropped,
+ * so a smaller limit gives the first keys of a larger one. An
+ * empty word gives no keys.
+ * Safe for concurrent use by multiple goroutines.
+ */
+func (m *M3) EncodeAll(in string) []string {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	limit := m.config.MaxKeys
+	if limit < 1 {
+		limit = DEFAULT_MAX_KEYS
+	}
+
+	s.expand = true
+	s.setWord(in)
+	s.run()
+	primary, secondary := truncateKeys(string(s.primary), string(s.secondary), s.metaphLength)
+
+	if s.current < s.length {
+		// stopped at the key length - finish the word,
+		// as other combinations may give shorter keys
+		s.metaphLength = math.MaxInt32
+		s.run()
+	}
+	s.expand = false
+
+	if primary == "" && secondary == "" {
+		return nil
+	}
+
+	keys := []string{primary}
+	if secondary != "" {
+		keys = append(keys, secondary)
+	}
+
+	seen := make(map[string]bool, limit)
+	for _, key := range keys {
+		seen[key] = true
+	}
+
+	for _, key := range s.combineChoices(limit, m.config.KeyLength) {
+		if len(keys) >= limit {
+			break
+		}
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			keys = append(keys, string(key))
+		}
+	}
+
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	return keys
+}
+
+/**
+ * Builds the keys of every combination of the choices recorded
+ * in m.choices, up to limit distinct keys.
+ *
+ * @param limit largest number of keys to build
+ * @param keyLength length keys are truncated to
+ * @return the keys, the one taking every main encoding first
+ */
+func (m *metaph) combineChoices(limit, keyLength int) [][]byte {
+	keys := [][]byte{nil}
+	seen := make(map[string]bool, limit)
+
+	for _, c := range m.choices {
+		n := len(keys)
+		for i := 0; i < n; i++ {
+			if c.alt != c.main && len(keys) < limit {
+				alt := append([]byte(nil), keys[i]...)
+				keys = append(keys, appendKey(alt, c.alt, keyLength))
+			}
+			keys[i] = appendKey(keys[i], c.main, keyLength)
+		}
+
+		if len(keys) == n {
+			continue
+		}
+
+		// combinations often meet again, e.g. once
+		// truncated to the key length
+		for key := range seen {
+			delete(seen, key)
+		}
+		unique := keys[:0]
+		for _, key := range keys {
+			if !seen[string(key)] {
+				seen[string(key)] = true
+				unique = append(unique, key)
+			}
+		}
+		keys = unique
+	}
+
+	return keys
+}
+
+/**
+ * Appends add to key the way metaphAdd does, keeping at
+ * most keyLength characters.
+ */
+func appendKey(key []byte, add string, keyLength int) []byte {
+	if len(key) >= keyLength {
+		return key
+	}
+
+	if add == "A" && (len(key) > 0) && (key[len(key)-1] == 'A') {
+		return key
+	}
+
+	key = append(key, add...)
+	if len(key) > keyLength {
+		key = key[:keyLength]
+	}
+	return key
+}
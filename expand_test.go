@@ -0,0 +1,66 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeAll(t *testing.T) {
+	short, err := NewWithOptions(WithKeyLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		m    *M3
+		word string
+		want []string
+	}{
+		// the first 'W', "CH" and the 'W' before "SKI" can
+		// each be sounded two ways
+		{New(), "Wojciechowski", []string{"ASKSK", "FSXFSK", "FSKSK", "ASXSK", "FSXSK", "ASKFSK", "FSKFSK", "ASXFSK"}},
+		{short, "Wojciechowski", []string{"ASKS", "FSXF", "FSKS", "ASXS", "FSXS", "ASKF", "FSKF", "ASXF"}},
+		{New(), "Smith", []string{"SM0", "XMT", "XM0", "SMT"}},
+		{New(), "Xavier", []string{"SFR"}},
+		{New(), "", nil},
+	} {
+		got := test.m.EncodeAll(test.word)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("KeyLength %d: EncodeAll(%q) = %q, want %q", test.m.Config().KeyLength, test.word, got, test.want)
+		}
+
+		// the keys of Encode come first
+		primary, secondary := test.m.Encode(test.word)
+		want := []string{primary}
+		if secondary != "" {
+			want = append(want, secondary)
+		}
+		if primary != "" && (len(got) < len(want) || !reflect.DeepEqual(got[:len(want)], want)) {
+			t.Errorf("EncodeAll(%q) = %q, Encode gives %q, %q", test.word, got, primary, secondary)
+		}
+	}
+}
+
+func TestEncodeAllMaxKeys(t *testing.T) {
+	all := New().EncodeAll("Wojciechowski")
+	if len(all) != 8 {
+		t.Fatalf("EncodeAll(%q) = %q, want 8 keys", "Wojciechowski", all)
+	}
+
+	for maxKeys := 0; maxKeys <= len(all)+1; maxKeys++ {
+		m, err := NewWithOptions(WithMaxKeys(maxKeys))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 0 stands for DEFAULT_MAX_KEYS, which is more than
+		// the word has
+		want := all
+		if maxKeys > 0 && maxKeys < len(all) {
+			want = all[:maxKeys]
+		}
+		if got := m.EncodeAll("Wojciechowski"); !reflect.DeepEqual(got, want) {
+			t.Errorf("MaxKeys %d: EncodeAll(%q) = %q, want %q", maxKeys, "Wojciechowski", got, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package metaphone3
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+)
+
+/**
+ * Step is one entry of the trace returned by Explain: a rule that
+ * fired, the part of the input it consumed, and what it appended
+ * to the keys.
+ */
+type Step struct {
+	/** Rune offset in the input of the first character consumed. */
+	Start int `json:"start"`
+
+	/** Rune offset in the input just past the last character consumed. */
+	End int `json:"end"`
+
+	/** Upper cased (and normalized) characters consumed. */
+	Input string `json:"input"`
+
+	/** Name of the rule, e.g. "encode_CH_To_X", or the pattern of a
+	 * custom rule (see WithRules) followed by its word conditions,
+	 * e.g. "(G)!{G|H}. prefix:NG". Characters that are skipped
+	 * without any rule firing are reported as "skip". */
+	Rule string `json:"rule"`
+
+	/** Characters appended to the primary key. */
+	Primary string `json:"primary"`
+
+	/** Characters appended to the alternate key. */
+	Alternate string `json:"alternate"`
+}
+
+/**
+ * Formats the step as e.g. `[2,4) "CH" encode_CH_To_X +X/+K`.
+ */
+func (s Step) String() string {
+	return fmt.Sprintf("[%d,%d) %q %s +%s/+%s", s.Start, s.End, s.Input, s.Rule, s.Primary, s.Alternate)
+}
+
+/**
+ * Encodes word the way Encode does, and returns the ordered list
+ * of rules that produced the keys. The trace covers the whole word,
+ * however long Config.KeyLength is: concatenating the Primary (or
+ * Alternate) fields of the steps gives the key before truncation,
+ * i.e. Key.FullPrimary (or Key.FullAlternate) of EncodeKey.
+ *
+ * When one rule calls another, the innermost rule that appended
+ * to the keys is reported. A single piece of input may give more
+ * than one step, e.g. when a vowel is interpolated before an 'L'.
+ */
+func (m *M3) Explain(word string) []Step {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	// trace to the end of the word, not just up to the key length
+	s.metaphLength = math.MaxInt32
+	s.runTraced(word)
+
+	steps := make([]Step, len(s.steps))
+	copy(steps, s.steps)
+	return steps
+}
+
+/**
+ * Runs the main loop over word, recording the steps in m.steps.
+ */
+func (m *metaph) runTraced(word string) {
+	m.trace = true
+	m.steps = m.steps[:0]
+	m.setWord(word)
+	m.run()
+	m.trace = false
+}
+
+/**
+ * Marks the start of a main loop iteration.
+ */
+func (m *metaph) traceBegin() {
+	m.stepStart = m.current
+	m.stepFirst = len(m.steps)
+}
+
+/**
+ * Records what a call to metaphAdd appended, and which
+ * rule made the call.
+ *
+ * @param primaryLen length of m.primary before the call
+ * @param secondaryLen length of m.secondary before the call
+ */
+func (m *metaph) traceAdd(primaryLen, secondaryLen int) {
+	rule := m.ruleName
+	if rule == "" {
+		rule = callingRule()
+	}
+
+	m.steps = append(m.steps, Step{
+		Rule:      rule,
+		Primary:   string(m.primary[primaryLen:]),
+		Alternate: string(m.secondary[secondaryLen:]),
+	})
+}
+
+/**
+ * Completes the steps of a main loop iteration with the
+ * span of input it consumed. An iteration that appended
+ * nothing is recorded as a step of its own.
+ */
+func (m *metaph) traceEnd() {
+	start, end := m.stepStart, m.current
+	if end > m.length {
+		end = m.length
+	}
+	if end < start {
+		start, end = end, start
+	}
+
+	if m.stepFirst == len(m.steps) {
+		m.steps = append(m.steps, Step{Rule: m.silentRule(start)})
+	}
+
+	input := m.spanText(start, end)
+	if end > start {
+		// the letters of an expanded ligature all map to the
+		// ligature, so a step taking only the first of them
+		// must still end past it
+		last := m.inputOffset(end-1) + 1
+		start, end = m.inputOffset(start), m.inputOffset(end)
+		if end < last {
+			end = last
+		}
+	} else {
+		start, end = m.inputOffset(start), m.inputOffset(end)
+	}
+	for i := m.stepFirst; i < len(m.steps); i++ {
+		m.steps[i].Start, m.steps[i].End, m.steps[i].Input = start, end, input
+		if m.steps[i].Rule == "run" {
+			// added directly by the main loop
+			m.steps[i].Rule = "Encode"
+		}
+	}
+}
+
+/**
+ * Names the handler that consumed the character at 'at'
+ * without appending anything.
+ */
+func (m *metaph) silentRule(at int) string {
+	c := m.charAt(at)
+	switch {
+	case c >= 'A' && c <= 'Z' && !isVowel(c):
+		return "encode_" + string(c)
+	case isVowel(c):
+		return "encode_Vowels"
+	}
+	return "skip"
+}
+
+/**
+ * Returns the upper cased input from rune offset start up to end.
+ */
+func (m *metaph) spanText(start, end int) string {
+	return string(m.inWord[start:end])
+}
+
+/**
+ * Returns the name of the innermost rule on the call stack
+ * above metaphAdd and its Exact/Approx variants.
+ */
+func callingRule() string {
+	var pcs [8]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+		if !strings.HasPrefix(name, "metaphAdd") && !strings.HasPrefix(name, "traceAdd") {
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
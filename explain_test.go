@@ -0,0 +1,44 @@
+package metaphone3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainCoversFullKey(t *testing.T) {
+	for _, keyLength := range []int{1, 2, 4, DEFAULT_MAX_KEY_LENGTH, MAX_KEY_ALLOCATION} {
+		m, err := NewWithOptions(WithKeyLength(keyLength))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, word := range []string{"Wojciechowski", "Schwarzenegger", "Knight", "Thompson", "Xavier", "a", ""} {
+			var primary, alternate strings.Builder
+			for _, step := range m.Explain(word) {
+				primary.WriteString(step.Primary)
+				alternate.WriteString(step.Alternate)
+			}
+
+			k := m.EncodeKey(word)
+			if primary.String() != k.FullPrimary || alternate.String() != k.FullAlternate {
+				t.Errorf("KeyLength %d, Explain(%q) traces %q/%q, want %q/%q",
+					keyLength, word, primary.String(), alternate.String(), k.FullPrimary, k.FullAlternate)
+			}
+		}
+	}
+}
+
+func TestExplainWojciechowski(t *testing.T) {
+	m, err := NewWithOptions(WithKeyLength(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var primary strings.Builder
+	for _, step := range m.Explain("Wojciechowski") {
+		primary.WriteString(step.Primary)
+	}
+	if got, want := primary.String(), "ASKSK"; got != want {
+		t.Errorf("Explain traces %q, want %q", got, want)
+	}
+}
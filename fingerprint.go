@@ -0,0 +1,220 @@
+package metaphone3
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/** Version of the Metaphone 3 rules implemented by this package. */
+const VERSION = "2.1.3"
+
+/**
+ * Returned (wrapped) whenever keys or indexes produced under
+ * different encoding settings are compared.
+ */
+var ErrFingerprintMismatch = errors.New("metaphone3: fingerprint mismatch")
+
+/**
+ * Fingerprint identifies everything that affects the keys an encoder
+ * produces: the algorithm version, vowel and exact encoding, key
+ * length and, when not the defaults, input normalization and the
+ * customizations of Config.Customization. Settings
+ * that only decide which words get encoded, such as Config.MaxKeys
+ * and Config.Separators, are left out. Keys are only comparable
+ * when their fingerprints are equal. The format is stable,
+ * e.g. "M3/2.1.3/V0E1L8", and safe to store next to an index or
+ * inside a key (see Tag).
+ */
+type Fingerprint string
+
+/** Separates the fingerprint from the key in a tagged key. */
+const fingerprintSeparator = ":"
+
+/**
+ * Returns the fingerprint of keys encoded with c.
+ */
+func (c Config) Fingerprint() Fingerprint {
+	f := fmt.Sprintf("M3/%s/V%dE%dL%d", VERSION, boolDigit(c.EncodeVowels), boolDigit(c.EncodeExact), c.KeyLength)
+	if !c.Normalization.IsZero() {
+		f += "/N" + c.Normalization.code()
+	}
+	if c.Customization != "" {
+		f += "/X" + c.Customization
+	}
+	return Fingerprint(f)
+}
+
+/**
+ * Returns the fingerprint of keys this encoder produces.
+ */
+func (m *M3) Fingerprint() Fingerprint { return m.fingerprint }
+
+/**
+ * Works out the customization and fingerprint of the encoder,
+ * which change only when it is built or its settings are, so
+ * that calls that return a Config or tag keys need not hash
+ * the customizations.
+ */
+func (m *M3) settle() {
+	m.customized = m.customization()
+	m.fingerprint = m.Config().Fingerprint()
+}
+
+/**
+ * Returns the Config.Customization of the encoder: a hash of
+ * the exception sets, rule set, Rules and Normalizer it was
+ * given, or "" if it has none of them besides the default
+ * exception sets.
+ *
+ * Rules and Normalizers are code, so they are known only by
+ * their type and, if they have one, their String method.
+ */
+func (m *M3) customization() string {
+	h, custom := fnvOffset, false
+	add := func(tag string, digest uint64) {
+		h, custom = fnvAddUint64(fnvAdd(h, tag), digest), true
+	}
+
+	if d := m.exceptions.digest(); d != defaultExceptionsDigest {
+		add("E", d)
+	}
+	if m.rules != nil && m.rules.digest != 0 {
+		add("R", m.rules.digest)
+	}
+
+	if len(m.hooks) > 0 {
+		letters := make([]rune, 0, len(m.hooks))
+		for letter := range m.hooks {
+			letters = append(letters, letter)
+		}
+		sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+		d := fnvOffset
+		for _, letter := range letters {
+			d = fnvAdd(d, string(letter))
+			for _, r := range m.hooks[letter] {
+				d = fnvAdd(d, fmt.Sprintf("%T\x00%s\x00", r, hookName(r)))
+			}
+		}
+		add("H", d)
+	}
+
+	if m.normalizer != nil {
+		name := fmt.Sprintf("%T", m.normalizer)
+		if s, ok := m.normalizer.(fmt.Stringer); ok {
+			name += "\x00" + s.String()
+		}
+		add("N", fnvAdd(fnvOffset, name))
+	}
+
+	if !custom {
+		return ""
+	}
+	return fmt.Sprintf("%016x", h)
+}
+
+/** Parameters of the 64 bit FNV-1a hash. */
+const (
+	fnvOffset uint64 = 14695981039346656037
+	fnvPrime  uint64 = 1099511628211
+)
+
+/** Adds the bytes of s to the FNV-1a hash h. */
+func fnvAdd(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime
+	}
+	return h
+}
+
+/** Adds the bytes of v, low byte first, to the FNV-1a hash h. */
+func fnvAddUint64(h, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= fnvPrime
+		v >>= 8
+	}
+	return h
+}
+
+/**
+ * Checks that keys made under f can be compared with keys made
+ * under other.
+ *
+ * @return nil if they match, an error wrapping ErrFingerprintMismatch if not
+ */
+func (f Fingerprint) Check(other Fingerprint) error {
+	if f != other {
+		return fmt.Errorf("%w: %q vs %q", ErrFingerprintMismatch, f, other)
+	}
+	return nil
+}
+
+/**
+ * Attaches f to key so that it can be stored and later compared
+ * with CompareTagged. An empty key stays empty.
+ */
+func (f Fingerprint) Tag(key string) string {
+	if key == "" {
+		return ""
+	}
+	return string(f) + fingerprintSeparator + key
+}
+
+/**
+ * Splits a key made by Fingerprint.Tag back into its parts.
+ *
+ * @return the fingerprint and the bare key, or an error if tagged
+ * carries no fingerprint
+ */
+func ParseTagged(tagged string) (Fingerprint, string, error) {
+	i := strings.LastIndex(tagged, fingerprintSeparator)
+	if i < 1 {
+		return "", "", fmt.Errorf("metaphone3: key %q has no fingerprint", tagged)
+	}
+	return Fingerprint(tagged[:i]), tagged[i+1:], nil
+}
+
+/**
+ * Compares two tagged keys.
+ *
+ * @return whether the keys are equal, or an error wrapping
+ * ErrFingerprintMismatch if they were made with different settings
+ */
+func CompareTagged(a, b string) (bool, error) {
+	fa, ka, err := ParseTagged(a)
+	if err != nil {
+		return false, err
+	}
+
+	fb, kb, err := ParseTagged(b)
+	if err != nil {
+		return false, err
+	}
+
+	if err := fa.Check(fb); err != nil {
+		return false, err
+	}
+
+	return ka == kb, nil
+}
+
+/**
+ * Like Encode, but each non-empty key carries the encoder's
+ * fingerprint (see Fingerprint.Tag).
+ */
+func (m *M3) EncodeTagged(in string) (primary, secondary string) {
+	f := m.Fingerprint()
+	primary, secondary = m.Encode(in)
+	return f.Tag(primary), f.Tag(secondary)
+}
+
+func boolDigit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
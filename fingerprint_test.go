@@ -0,0 +1,222 @@
+package metaphone3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestFingerprint(t *testing.T) {
+	for _, test := range []struct {
+		opts []Option
+		want Fingerprint
+	}{
+		{nil, "M3/2.1.3/V0E0L8"},
+		{[]Option{WithEncodeVowels(true), WithEncodeExact(true), WithKeyLength(4)}, "M3/2.1.3/V1E1L4"},
+		{[]Option{WithMaxKeys(1)}, "M3/2.1.3/V0E0L8"},
+		{[]Option{WithSeparators(" ")}, "M3/2.1.3/V0E0L8"},
+		{[]Option{WithSeparators(":/")}, "M3/2.1.3/V0E0L8"},
+	} {
+		m, err := NewWithOptions(test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.Fingerprint(); got != test.want {
+			t.Errorf("%v: Fingerprint() = %q, want %q", m.Config(), got, test.want)
+		}
+	}
+}
+
+func TestFingerprintFollowsSetters(t *testing.T) {
+	m := New()
+	m.SetEncodeVowels(true)
+	m.SetEncodeExact(true)
+	m.SetKeyLength(4)
+	if got, want := m.Fingerprint(), Fingerprint("M3/2.1.3/V1E1L4"); got != want {
+		t.Errorf("Fingerprint() = %q after the setters, want %q", got, want)
+	}
+
+	m.SetKeyLength(MAX_KEY_ALLOCATION + 1)
+	if got, want := m.Fingerprint(), Fingerprint("M3/2.1.3/V1E1L32"); got != want {
+		t.Errorf("Fingerprint() = %q after SetKeyLength beyond the limit, want %q", got, want)
+	}
+}
+
+func TestFingerprintComputedOnce(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader("^(MR)$ -> MSTR\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewWithOptions(WithRules(rules), WithNormalizer(upperNormalizer("upper")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := m.Fingerprint(), m.Config().Fingerprint(); got != want {
+		t.Errorf("Fingerprint() = %q, Config().Fingerprint() = %q", got, want)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { m.Fingerprint() }); allocs != 0 {
+		t.Errorf("Fingerprint allocates %v times, want the cached value", allocs)
+	}
+}
+
+func TestFingerprintIgnoresSeparators(t *testing.T) {
+	a, err := NewWithOptions(WithSeparators(" "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewWithOptions(WithSeparators("-'/:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("fingerprints differ: %q vs %q", a.Fingerprint(), b.Fingerprint())
+	}
+
+	pa, _ := a.EncodeTagged("Smith")
+	pb, _ := b.EncodeTagged("Smith")
+	if equal, err := CompareTagged(pa, pb); err != nil || !equal {
+		t.Errorf("CompareTagged(%q, %q) = %t, %v, want true, nil", pa, pb, equal, err)
+	}
+}
+
+func TestCompareTaggedMismatch(t *testing.T) {
+	a := New()
+	b, err := NewWithOptions(WithEncodeVowels(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pa, _ := a.EncodeTagged("Smith")
+	pb, _ := b.EncodeTagged("Smith")
+	if _, err := CompareTagged(pa, pb); !errors.Is(err, ErrFingerprintMismatch) {
+		t.Errorf("CompareTagged(%q, %q) error = %v, want ErrFingerprintMismatch", pa, pb, err)
+	}
+}
+
+/** A Normalizer that upper cases, named for the fingerprint. */
+type upperNormalizer string
+
+func (n upperNormalizer) Normalize(dst []rune, dstPos []int, src []rune) ([]rune, []int) {
+	for i, r := range src {
+		dst, dstPos = append(dst, unicode.ToUpper(r)), append(dstPos, i)
+	}
+	return dst, dstPos
+}
+
+func (n upperNormalizer) String() string { return string(n) }
+
+func TestFingerprintCustomization(t *testing.T) {
+	plain := New().Fingerprint()
+
+	extended := JAltY.Clone()
+	extended.Add("JAKOB")
+	rules, err := ParseRules(strings.NewReader("^(MR)$ -> MSTR\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRules, err := ParseRules(strings.NewReader("^(MR)$ -> MR\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyRules, err := ParseRules(strings.NewReader("# nothing\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides := NewOverrides()
+	if err := overrides.Set("ACME", "AKM", ""); err != nil {
+		t.Fatal(err)
+	}
+	skip := RuleFunc(func(c *RuleContext) bool { return true })
+
+	for _, test := range []struct {
+		name string
+		opts []Option
+		same bool
+	}{
+		{"default exceptions", []Option{WithExceptions(DefaultExceptions())}, true},
+		{"cloned default exceptions", []Option{WithExceptions(Exceptions{JAltY: JAltY.Clone()})}, true},
+		{"extended exceptions", []Option{WithExceptions(Exceptions{JAltY: extended})}, false},
+		{"empty override table", []Option{WithOverrides(NewOverrides())}, true},
+		{"override table", []Option{WithOverrides(overrides)}, true},
+		{"empty rule set", []Option{WithRules(emptyRules)}, true},
+		{"rule set", []Option{WithRules(rules)}, false},
+		{"rule", []Option{WithRule('B', skip)}, false},
+		{"normalizer", []Option{WithNormalizer(upperNormalizer("upper"))}, false},
+	} {
+		m, err := NewWithOptions(test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := m.Fingerprint()
+		if same := f == plain; same != test.same {
+			t.Errorf("%s: fingerprint %q, plain %q, want equal %t", test.name, f, plain, test.same)
+		}
+		if !test.same && m.Config().Customization == "" {
+			t.Errorf("%s: Config().Customization is empty", test.name)
+		}
+		if k := m.EncodeKey("Smith"); k.Fingerprint() != f {
+			t.Errorf("%s: EncodeKey fingerprint %q, want %q", test.name, k.Fingerprint(), f)
+		}
+	}
+
+	// different customizations of the same kind differ
+	for _, pair := range [][2][]Option{
+		{{WithRules(rules)}, {WithRules(otherRules)}},
+		{{WithRule('B', skip)}, {WithRule('C', skip)}},
+		{{WithNormalizer(upperNormalizer("upper"))}, {WithNormalizer(upperNormalizer("upper v2"))}},
+		{{WithExceptions(Exceptions{JAltY: extended})}, {WithExceptions(Exceptions{SWAltSV: extended})}},
+	} {
+		a, err := NewWithOptions(pair[0]...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := NewWithOptions(pair[1]...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Fingerprint() == b.Fingerprint() {
+			t.Errorf("%v and %v share fingerprint %q", a.Config(), b.Config(), a.Fingerprint())
+		}
+	}
+}
+
+func TestFingerprintIgnoresOverrides(t *testing.T) {
+	o := NewOverrides()
+	m, err := NewWithOptions(WithOverrides(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, _ := m.EncodeTagged("Acme")
+	version := o.Version()
+	if err := o.Set("ACME", "AKN", ""); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := m.EncodeTagged("Acme")
+	if same, err := CompareTagged(before, after); err != nil || same {
+		t.Errorf("CompareTagged(%q, %q) = %t, %v, want false, nil", before, after, same, err)
+	}
+	if version != "" || o.Version() == "" {
+		t.Errorf("Version() = %q before setting an entry, %q after", version, o.Version())
+	}
+
+	// the version depends on the entries, not the order they were set in
+	a, b := NewOverrides(), NewOverrides()
+	for _, set := range [][2]string{{"ACME", "AKM"}, {"NGUYEN", "NKN"}} {
+		if err := a.Set(set[0], set[1], ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, set := range [][2]string{{"NGUYEN", "NN"}, {"NGUYEN", "NKN"}, {"ACME", "AKM"}} {
+		if err := b.Set(set[0], set[1], ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.Version() != b.Version() {
+		t.Errorf("versions of equal tables differ: %s vs %s", a.Version(), b.Version())
+	}
+}
@@ -0,0 +1,187 @@
+//go:build go1.18
+// +build go1.18
+
+package metaphone3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+/** How long encoding one input in every mode may take. */
+const fuzzTimeout = 10 * time.Second
+
+/**
+ * Returns encoders for every combination of the options that
+ * change what the main loop does: vowels, exact, a short and a
+ * long key length, and input normalization.
+ */
+func fuzzEncoders(t testing.TB) []*M3 {
+	var encoders []*M3
+	for _, keyLength := range []int{1, DEFAULT_MAX_KEY_LENGTH, MAX_KEY_ALLOCATION} {
+		for _, n := range []Normalization{{}, {
+			FoldWidth: true, Compose: true, ExpandLigatures: true,
+			Punctuation: PunctuationDrop, Turkish: true, StripAccents: true,
+		}} {
+			for _, vowels := range []bool{false, true} {
+				for _, exact := range []bool{false, true} {
+					m, err := NewWithOptions(WithEncodeVowels(vowels), WithEncodeExact(exact),
+						WithKeyLength(keyLength), WithNormalization(n))
+					if err != nil {
+						t.Fatal(err)
+					}
+					encoders = append(encoders, m)
+				}
+			}
+		}
+	}
+	return encoders
+}
+
+/** Reports a key longer than keyLength or with a character outside KEY_ALPHABET. */
+func checkKey(t *testing.T, m *M3, call, word, key string, keyLength int) {
+	if len(key) > keyLength {
+		t.Errorf("%v: %s(%q) key %q is longer than %d", m.Config(), call, word, key, keyLength)
+	}
+	if i := strings.IndexFunc(key, func(r rune) bool { return !strings.ContainsRune(KEY_ALPHABET, r) }); i >= 0 {
+		t.Errorf("%v: %s(%q) key %q has %q, not in KEY_ALPHABET", m.Config(), call, word, key, key[i])
+	}
+}
+
+/**
+ * Checks that EncodeAll gives at most MaxKeys distinct, valid keys,
+ * starting with those of Encode.
+ */
+func checkEncodeAll(t *testing.T, m *M3, word, primary, alternate string) {
+	keys := m.EncodeAll(word)
+	if len(keys) > m.Config().MaxKeys {
+		t.Errorf("%v: EncodeAll(%q) gave %d keys, more than MaxKeys", m.Config(), word, len(keys))
+	}
+	if primary == "" && alternate == "" {
+		if len(keys) != 0 {
+			t.Errorf("%v: EncodeAll(%q) = %q, Encode gives no keys", m.Config(), word, keys)
+		}
+		return
+	}
+	if len(keys) == 0 || keys[0] != primary || (alternate != "" && (len(keys) < 2 || keys[1] != alternate)) {
+		t.Errorf("%v: EncodeAll(%q) = %q, Encode gives %q, %q", m.Config(), word, keys, primary, alternate)
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			t.Errorf("%v: EncodeAll(%q) = %q gives %q twice", m.Config(), word, keys, key)
+		}
+		seen[key] = true
+		checkKey(t, m, "EncodeAll", word, key, m.Config().KeyLength)
+	}
+}
+
+/**
+ * Checks that EncodePhrase gives each token the keys of Encode, with
+ * a non-empty span inside the phrase, and joins their keys.
+ */
+func checkEncodePhrase(t *testing.T, m *M3, phrase string, runes int) {
+	pk := m.EncodePhrase(phrase)
+	var primary, alternate strings.Builder
+	end := 0
+	for _, token := range pk.Tokens {
+		if p, a := m.Encode(token.Token); token.Primary != p || token.Alternate != a {
+			t.Errorf("%v: EncodePhrase(%q) token %+v, Encode gives %q, %q", m.Config(), phrase, token, p, a)
+		}
+		if token.Span.Start < end || token.Span.End > runes || token.Span.Start >= token.Span.End {
+			t.Errorf("%v: EncodePhrase(%q) token %+v is outside the phrase or out of order", m.Config(), phrase, token)
+		}
+		end = token.Span.End
+		primary.WriteString(token.Primary)
+		if token.Alternate == "" {
+			alternate.WriteString(token.Primary)
+		} else {
+			alternate.WriteString(token.Alternate)
+		}
+	}
+	wantAlternate := alternate.String()
+	if wantAlternate == primary.String() {
+		wantAlternate = ""
+	}
+	if pk.Primary != primary.String() || pk.Alternate != wantAlternate {
+		t.Errorf("%v: EncodePhrase(%q) = %q, %q, its tokens give %q, %q", m.Config(), phrase, pk.Primary, pk.Alternate, primary.String(), wantAlternate)
+	}
+}
+
+/**
+ * Encodes every input with each entry point under every option
+ * combination, and checks that nothing panics or hangs, that keys
+ * stay within KeyLength and KEY_ALPHABET, and that the entry points,
+ * EncodeAll and EncodePhrase among them, agree with Encode.
+ */
+func FuzzEncode(f *testing.F) {
+	for _, seed := range []string{
+		"", "a", "Smith", "Schwarzenegger", "Wojciechowski", "Muñoz", "Straße",
+		"ÐÞ", "ẞ", "\xff\xfe", "́", strings.Repeat("Ch", 40),
+	} {
+		f.Add(seed)
+	}
+
+	encoders := fuzzEncoders(f)
+	f.Fuzz(func(t *testing.T, word string) {
+		// the fuzzer has no time limit of its own, so a word the
+		// main loop never finishes would only stall it
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			runes := utf8.RuneCountInString(word)
+			for _, m := range encoders {
+				keyLength := m.Config().KeyLength
+				primary, alternate := m.Encode(word)
+				checkKey(t, m, "Encode", word, primary, keyLength)
+				checkKey(t, m, "Encode", word, alternate, keyLength)
+
+				p, a := m.AppendEncode(nil, nil, []byte(word))
+				if !bytes.Equal(p, []byte(primary)) || !bytes.Equal(a, []byte(alternate)) {
+					t.Errorf("%v: AppendEncode(%q) = %q, %q, Encode gives %q, %q", m.Config(), word, p, a, primary, alternate)
+				}
+
+				k := m.EncodeKey(word)
+				if k.Primary != primary || (k.HasAlternate && k.Alternate != alternate) ||
+					!strings.HasPrefix(k.FullPrimary, k.Primary) || !strings.HasPrefix(k.FullAlternate, k.Alternate) {
+					t.Errorf("%v: EncodeKey(%q) = %+v, Encode gives %q, %q", m.Config(), word, k, primary, alternate)
+				}
+
+				al := m.EncodeAligned(word)
+				if al.Primary != primary || al.Secondary != alternate {
+					t.Errorf("%v: EncodeAligned(%q) keys %q, %q, Encode gives %q, %q", m.Config(), word, al.Primary, al.Secondary, primary, alternate)
+				}
+				for _, spans := range [][]Span{al.PrimarySpans, al.SecondarySpans} {
+					for _, s := range spans {
+						if s.Start < 0 || s.End > runes || s.Start > s.End {
+							t.Errorf("%v: EncodeAligned(%q) span %v is outside the word", m.Config(), word, s)
+						}
+					}
+				}
+
+				var explained strings.Builder
+				for _, s := range m.Explain(word) {
+					if s.Start < 0 || s.End > runes || s.Start > s.End {
+						t.Errorf("%v: Explain(%q) step %v is outside the word", m.Config(), word, s)
+					}
+					explained.WriteString(s.Primary)
+				}
+				if explained.String() != k.FullPrimary {
+					t.Errorf("%v: Explain(%q) steps give %q, EncodeKey gives %q", m.Config(), word, explained.String(), k.FullPrimary)
+				}
+
+				checkEncodeAll(t, m, word, primary, alternate)
+				checkEncodePhrase(t, m, word, runes)
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(fuzzTimeout):
+			t.Fatalf("Encode(%q) did not return within %v", word, fuzzTimeout)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package metaphone3
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/**
+ * Rule is a custom handler for a letter, registered with WithRule.
+ * Before the built-in handler for the letter runs, the encoder
+ * calls Apply with the state of the encoding. The rule either
+ * handles the letter, appending keys with Add and consuming
+ * input with Advance, and returns true, or declines by returning
+ * false, in which case whatever it appended is undone and the
+ * next rule, or the built-in handler, runs.
+ *
+ * A rule that returns true without advancing is taken to have
+ * consumed the current letter. Rules are called from any
+ * goroutine that encodes, so must be safe for concurrent use.
+ */
+type Rule interface {
+	Apply(c *RuleContext) bool
+}
+
+/**
+ * RuleFunc adapts a function to the Rule interface.
+ */
+type RuleFunc func(c *RuleContext) bool
+
+/** Implements Rule. */
+func (f RuleFunc) Apply(c *RuleContext) bool { return f(c) }
+
+/**
+ * RuleContext is the view of an encoding in progress given to a
+ * Rule. It is only valid during the call to Apply.
+ */
+type RuleContext struct {
+	m *metaph
+}
+
+/**
+ * Returns the word being encoded, upper cased (and normalized),
+ * one rune per letter. Must not be modified.
+ */
+func (c *RuleContext) Word() []rune { return c.m.inWord[:c.m.length] }
+
+/** Returns the index in Word of the letter being encoded. */
+func (c *RuleContext) Current() int { return c.m.current }
+
+/** Returns the number of letters in Word. */
+func (c *RuleContext) Length() int { return c.m.length }
+
+/**
+ * Returns the letter at index at of Word, or 0 if at is
+ * out of range.
+ */
+func (c *RuleContext) CharAt(at int) rune { return c.m.charAt(at) }
+
+/**
+ * Tests whether one of strs is found at index start of Word,
+ * each being length letters long. Out of range indexes
+ * simply do not match.
+ */
+func (c *RuleContext) StringAt(start, length int, strs ...string) bool {
+	return c.m.stringAt(start, length, strs...)
+}
+
+/** Returns the primary key so far. */
+func (c *RuleContext) Primary() string { return string(c.m.primary) }
+
+/** Returns the alternate key so far. */
+func (c *RuleContext) Alternate() string { return string(c.m.secondary) }
+
+/** Reports whether non-initial vowels are being encoded. */
+func (c *RuleContext) EncodeVowels() bool { return c.m.encodeVowels }
+
+/** Reports whether consonants are being encoded exactly. */
+func (c *RuleContext) EncodeExact() bool { return c.m.encodeExact }
+
+/**
+ * Appends main to the primary key and alt to the alternate key,
+ * as the built-in handlers do: a vowel 'A' is not repeated.
+ * Characters that are not in KEY_ALPHABET are dropped, so that
+ * keys keep to it whatever a Rule adds.
+ */
+func (c *RuleContext) Add(main, alt string) { c.m.metaphAdd(keyChars(main), keyChars(alt)) }
+
+/**
+ * Returns key without the characters that are not in
+ * KEY_ALPHABET, or key itself if there are none.
+ */
+func keyChars(key string) string {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(KEY_ALPHABET, key[i]) < 0 {
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune(KEY_ALPHABET, r) {
+					return r
+				}
+				return -1
+			}, key)
+		}
+	}
+	return key
+}
+
+/** Consumes n letters of Word. */
+func (c *RuleContext) Advance(n int) { c.m.current += n }
+
+/**
+ * Registers r to run before the built-in handler of letter.
+ * Rules registered for the same letter run in the order they
+ * were registered, ahead of any WithRules rule set. The
+ * fingerprint records r by its type and, if r is a fmt.Stringer,
+ * its String; two plain RuleFuncs on the same letter look alike
+ * to it.
+ */
+func WithRule(letter rune, r Rule) Option {
+	return func(m *M3) {
+		letter = unicode.ToUpper(letter)
+
+		hooks := make(map[rune][]Rule, len(m.hooks)+1)
+		for l, rules := range m.hooks {
+			hooks[l] = rules
+		}
+		// copy, as the slices may be shared with other encoders
+		hooks[letter] = append(append([]Rule(nil), hooks[letter]...), r)
+		m.hooks = hooks
+	}
+}
+
+/**
+ * Runs the Rules registered for the current letter.
+ *
+ * @return true if encoding handled in this routine, false if not
+ */
+func (m *metaph) encode_Hooks() bool {
+	rules := m.hooks[m.charAt(m.current)]
+	if len(rules) == 0 {
+		return false
+	}
+
+	ctx := RuleContext{m}
+	for _, r := range rules {
+		current, primaryLen, secondaryLen := m.current, len(m.primary), len(m.secondary)
+		stepsLen, choicesLen := len(m.steps), len(m.choices)
+
+		if m.trace {
+			m.ruleName = hookName(r)
+		}
+		handled := r.Apply(&ctx)
+		m.ruleName = ""
+
+		if handled {
+			return true
+		}
+
+		// declined - undo anything it did
+		m.current, m.primary, m.secondary = current, m.primary[:primaryLen], m.secondary[:secondaryLen]
+		m.steps, m.choices = m.steps[:stepsLen], m.choices[:choicesLen]
+	}
+
+	return false
+}
+
+/**
+ * Names a Rule in the trace: its String method if it
+ * has one, its type otherwise.
+ */
+func hookName(r Rule) string {
+	if s, ok := r.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", r)
+}
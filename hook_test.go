@@ -0,0 +1,86 @@
+package metaphone3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleContextAddKeepsToKeyAlphabet(t *testing.T) {
+	for _, test := range []struct {
+		main, alt       string
+		primary, altKey string
+	}{
+		{"K", "K", "KNT", ""},
+		{"k", "X", "NT", "XNT"},
+		{"K-S", "K S", "KSNT", ""},
+		{"Ñ", "ÑK", "NT", "KNT"},
+		{"", "", "NT", ""},
+	} {
+		add := RuleFunc(func(c *RuleContext) bool {
+			if c.Current() != 0 {
+				return false
+			}
+			c.Add(test.main, test.alt)
+			return true
+		})
+
+		m, err := NewWithOptions(WithRule('Q', add))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		primary, alternate := m.Encode("Quant")
+		if primary != test.primary || alternate != test.altKey {
+			t.Errorf("Add(%q, %q): Encode(Quant) = %q, %q, want %q, %q", test.main, test.alt, primary, alternate, test.primary, test.altKey)
+		}
+		for _, key := range []string{primary, alternate} {
+			for _, r := range key {
+				if !strings.ContainsRune(KEY_ALPHABET, r) {
+					t.Errorf("Add(%q, %q): key %q has %q, which is not in KEY_ALPHABET", test.main, test.alt, key, r)
+				}
+			}
+		}
+	}
+}
+
+func TestRuleDeclineUndoes(t *testing.T) {
+	declined := RuleFunc(func(c *RuleContext) bool {
+		c.Add("X", "X")
+		c.Advance(3)
+		return false
+	})
+
+	m, err := NewWithOptions(WithRule('s', declined))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"Smith", "Schwarz", "Vasquez"} {
+		wantPrimary, wantAlternate := New().Encode(word)
+		if primary, alternate := m.Encode(word); primary != wantPrimary || alternate != wantAlternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q", word, primary, alternate, wantPrimary, wantAlternate)
+		}
+	}
+}
+
+func TestRuleOrder(t *testing.T) {
+	rule := func(key string) Rule {
+		return RuleFunc(func(c *RuleContext) bool {
+			c.Add(key, key)
+			c.Advance(1)
+			return true
+		})
+	}
+	rs, err := ParseRules(strings.NewReader("(B) -> F\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewWithOptions(WithRules(rs), WithRule('B', rule("V")), WithRule('B', rule("P")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primary, _ := m.Encode("Bob"); primary != "VV" {
+		t.Errorf("Encode(Bob) = %q, want VV from the first Rule", primary)
+	}
+}
@@ -0,0 +1,147 @@
+package metaphone3
+
+import (
+	"encoding/json"
+	"math"
+)
+
+/**
+ * Key is the full result of encoding one word: the (possibly
+ * truncated) keys that Encode returns, the untruncated keys they
+ * were cut from, and the settings that produced them.
+ *
+ * Key implements fmt.Stringer, encoding.TextMarshaler and
+ * json.Marshaler. The text form is the fingerprint-tagged
+ * "PRIMARY" or "PRIMARY,ALTERNATE"; the JSON form has every field.
+ */
+type Key struct {
+	/** Primary key, at most Config.KeyLength long. */
+	Primary string `json:"primary"`
+
+	/** Alternate key. Equal to Primary if HasAlternate is false. */
+	Alternate string `json:"alternate"`
+
+	/** Whether the word has an alternate key that differs from
+	 * the primary key after truncation. */
+	HasAlternate bool `json:"hasAlternate"`
+
+	/** Whether either key was cut to Config.KeyLength. */
+	Truncated bool `json:"truncated"`
+
+	/** Primary key before truncation. */
+	FullPrimary string `json:"fullPrimary"`
+
+	/** Alternate key before truncation. Equal to FullPrimary
+	 * if the word has no alternate pronunciation. */
+	FullAlternate string `json:"fullAlternate"`
+
+	/** Settings the key was encoded with. */
+	Config Config `json:"config"`
+}
+
+/**
+ * Encodes in and returns everything known about the result.
+ * Primary and Alternate are the same values Encode returns,
+ * except that Alternate repeats Primary instead of being empty.
+ */
+func (m *M3) EncodeKey(in string) Key {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	s.setWord(in)
+	s.run()
+	primary, secondary := string(s.primary), string(s.secondary)
+	fullPrimary, fullSecondary := primary, secondary
+
+	if s.current < s.length {
+		// stopped at the key length - finish the
+		// word to get the untruncated keys
+		s.metaphLength = math.MaxInt32
+		s.run()
+		fullPrimary, fullSecondary = string(s.primary), string(s.secondary)
+	}
+
+	return newKey(primary, secondary, fullPrimary, fullSecondary, m.Config())
+}
+
+/**
+ * Builds a Key from the keys of a run stopped at c.KeyLength
+ * and the keys of a run to the end of the word.
+ */
+func newKey(primary, secondary, fullPrimary, fullSecondary string, c Config) Key {
+	primary, secondary = truncateKeys(primary, secondary, c.KeyLength)
+	if fullSecondary == "" {
+		fullSecondary = fullPrimary
+	}
+
+	k := Key{
+		Primary:       primary,
+		Alternate:     secondary,
+		HasAlternate:  secondary != "",
+		Truncated:     len(fullPrimary) > c.KeyLength || len(fullSecondary) > c.KeyLength,
+		FullPrimary:   fullPrimary,
+		FullAlternate: fullSecondary,
+		Config:        c,
+	}
+	if !k.HasAlternate {
+		k.Alternate = k.Primary
+	}
+
+	return k
+}
+
+/**
+ * Returns the fingerprint of the settings the key was encoded with.
+ */
+func (k Key) Fingerprint() Fingerprint { return k.Config.Fingerprint() }
+
+/**
+ * Reports whether k and other sound alike, i.e. whether any of
+ * their primary and alternate keys are equal.
+ *
+ * @return an error wrapping ErrFingerprintMismatch if the keys were
+ * encoded with different settings
+ */
+func (k Key) Match(other Key) (bool, error) {
+	if err := k.Fingerprint().Check(other.Fingerprint()); err != nil {
+		return false, err
+	}
+
+	if k.Primary == "" || other.Primary == "" {
+		return false, nil
+	}
+
+	return k.Primary == other.Primary || k.Primary == other.Alternate ||
+		k.Alternate == other.Primary || k.Alternate == other.Alternate, nil
+}
+
+/**
+ * Returns "PRIMARY", or "PRIMARY,ALTERNATE" when there is an
+ * alternate key.
+ */
+func (k Key) String() string {
+	if k.HasAlternate {
+		return k.Primary + "," + k.Alternate
+	}
+	return k.Primary
+}
+
+/**
+ * Returns the fingerprint-tagged String(), e.g.
+ * "M3/2.1.3/V0E0L8:ANT,ANTR".
+ */
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(string(k.Fingerprint()) + fingerprintSeparator + k.String()), nil
+}
+
+/**
+ * Marshals every field plus the fingerprint. Without this,
+ * encoding/json would use the lossy MarshalText form.
+ */
+func (k Key) MarshalJSON() ([]byte, error) {
+	type plainKey Key
+	return json.Marshal(struct {
+		plainKey
+		Fingerprint Fingerprint `json:"fingerprint"`
+	}{plainKey(k), k.Fingerprint()})
+}
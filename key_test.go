@@ -0,0 +1,152 @@
+package metaphone3
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeKey(t *testing.T) {
+	for _, test := range []struct {
+		keyLength                               int
+		word                                    string
+		primary, alternate, full, fullAlternate string
+		hasAlternate, truncated                 bool
+	}{
+		{4, "Smith", "SM0", "XMT", "SM0", "XMT", true, false},
+		{4, "Schmidt", "XMT", "XMT", "XMT", "XMT", false, false},
+		{4, "Thompson", "TMPS", "TMPS", "TMPSN", "TMPSN", false, true},
+		{4, "Wojciechowski", "ASKS", "FSXF", "ASKSK", "FSXFSK", true, true},
+		// the alternate differs only in what was cut off
+		{2, "Sanchez", "SN", "SN", "SNXS", "SNKS", false, true},
+		{4, "", "", "", "", "", false, false},
+	} {
+		m, err := NewWithOptions(WithKeyLength(test.keyLength))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		k := m.EncodeKey(test.word)
+		if k.Primary != test.primary || k.Alternate != test.alternate ||
+			k.FullPrimary != test.full || k.FullAlternate != test.fullAlternate {
+			t.Errorf("KeyLength %d: EncodeKey(%q) = %q/%q, full %q/%q, want %q/%q, full %q/%q", test.keyLength, test.word,
+				k.Primary, k.Alternate, k.FullPrimary, k.FullAlternate, test.primary, test.alternate, test.full, test.fullAlternate)
+		}
+		if k.HasAlternate != test.hasAlternate || k.Truncated != test.truncated {
+			t.Errorf("KeyLength %d: EncodeKey(%q) HasAlternate %t, Truncated %t, want %t, %t", test.keyLength, test.word,
+				k.HasAlternate, k.Truncated, test.hasAlternate, test.truncated)
+		}
+
+		primary, alternate := m.Encode(test.word)
+		if k.Primary != primary || (k.HasAlternate && k.Alternate != alternate) || (!k.HasAlternate && alternate != "") {
+			t.Errorf("KeyLength %d: EncodeKey(%q) = %v, Encode gives %q, %q", test.keyLength, test.word, k, primary, alternate)
+		}
+		if k.Config != m.Config() {
+			t.Errorf("EncodeKey(%q).Config = %v, want %v", test.word, k.Config, m.Config())
+		}
+	}
+}
+
+func TestKeyMarshal(t *testing.T) {
+	m, err := NewWithOptions(WithKeyLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		word, text string
+	}{
+		{"Smith", "M3/2.1.3/V0E0L4:SM0,XMT"},
+		{"Thompson", "M3/2.1.3/V0E0L4:TMPS"},
+		{"", "M3/2.1.3/V0E0L4:"},
+	} {
+		k := m.EncodeKey(test.word)
+		text, err := k.MarshalText()
+		if err != nil || string(text) != test.text {
+			t.Errorf("EncodeKey(%q).MarshalText() = %q, %v, want %q", test.word, text, err, test.text)
+		}
+
+		f, key, err := ParseTagged(string(text))
+		if err != nil || f != m.Fingerprint() || key != k.String() {
+			t.Errorf("ParseTagged(%q) = %q, %q, %v, want %q, %q", text, f, key, err, m.Fingerprint(), k.String())
+		}
+
+		b, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded struct {
+			Key
+			Fingerprint Fingerprint `json:"fingerprint"`
+		}
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", b, err)
+		}
+		if decoded.Key != k || decoded.Fingerprint != m.Fingerprint() {
+			t.Errorf("json round trip of %v gave %+v, fingerprint %q", k, decoded.Key, decoded.Fingerprint)
+		}
+	}
+
+	// a Key inside another value marshals with every field, not as text
+	b, err := json.Marshal(map[string]Key{"k": m.EncodeKey("Wojciechowski")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"k":{"primary":"ASKS","alternate":"FSXF","hasAlternate":true,"truncated":true,` +
+		`"fullPrimary":"ASKSK","fullAlternate":"FSXFSK","config":{"encodeVowels":false,"encodeExact":false,` +
+		`"keyLength":4,"maxKeyLength":32,"maxKeys":16,"separators":"","ambiguousParticles":false,"normalization":{}},"fingerprint":"M3/2.1.3/V0E0L4"}}`
+	if string(b) != want {
+		t.Errorf("json.Marshal = %s, want %s", b, want)
+	}
+}
+
+func TestKeyMatch(t *testing.T) {
+	m := New()
+	vowels, err := NewWithOptions(WithEncodeVowels(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		a, b  string
+		match bool
+	}{
+		{"Smith", "Smith", true},
+		{"Smith", "Schmidt", true}, // SM0,XMT and XMT share XMT
+		{"Schmidt", "Smith", true},
+		{"Smith", "Jones", false},
+		{"Schmitt", "Schmidt", true},
+		{"", "", false},
+		{"Smith", "", false},
+	} {
+		match, err := m.EncodeKey(test.a).Match(m.EncodeKey(test.b))
+		if err != nil || match != test.match {
+			t.Errorf("EncodeKey(%q).Match(EncodeKey(%q)) = %t, %v, want %t", test.a, test.b, match, err, test.match)
+		}
+	}
+
+	if _, err := m.EncodeKey("Smith").Match(vowels.EncodeKey("Smith")); !errors.Is(err, ErrFingerprintMismatch) {
+		t.Errorf("Match across settings error = %v, want ErrFingerprintMismatch", err)
+	}
+}
+
+func TestConfigCached(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader("^(MR)$ -> MSTR\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewWithOptions(WithRules(rules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// EncodeKey copies the Config into every Key, so it must
+	// not hash the customizations on each call
+	if c := m.Config(); c.Customization == "" || c.Customization != m.EncodeKey("Mr").Config.Customization {
+		t.Errorf("Config().Customization = %q, EncodeKey gives %q", c.Customization, m.EncodeKey("Mr").Config.Customization)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { m.Config() }); allocs != 0 {
+		t.Errorf("Config allocates %v times, want the cached value", allocs)
+	}
+}
@@ -0,0 +1,148 @@
+package metaphone3
+
+/**
+ * Handling of letters outside A-Z.
+ *
+ * The Latin-1 letters ß, Ç, Ñ, Ð, Þ, Š and Ž are encoded directly
+ * in the main loop of Encode, as in the reference implementation.
+ * The letters of the Latin Extended-A (U+0100-U+017F) and
+ * Latin Extended-B (U+0180-U+024F) blocks are mapped here onto the
+ * Metaphone 3 classes, by the sound the letter usually has in the
+ * languages that use it, falling back to the sound of its base
+ * letter:
+ *
+ * - vowels (isVowel), encoded like any other vowel. Those that sound
+ *   like 'E', 'I' or 'Y' are also front vowels (front_Vowel), which
+ *   softens a preceding 'C' or 'G':
+ *     Ā Ă Ą Ē Ĕ Ė Ę Ě Ĩ Ī Ĭ Į İ Ō Ŏ Ő Œ Ũ Ū Ŭ Ů Ű Ų Ŷ Ÿ, dutch Ĳ, welsh Ŵ,
+ *     Vietnamese Ơ Ư,
+ *     pinyin Ǎ Ǐ Ǒ Ǔ Ǖ Ǘ Ǚ Ǜ, and the remaining vowels of Latin
+ *     Extended-B (Ɛ Ə Ɔ Ɨ Ʉ Ǟ Ǫ Ȁ-Ȏ Ȕ Ȗ Ȧ-Ȳ ...)
+ *
+ * - consonants, in latinConsonants. Most encode to the key of their
+ *   base letter (Ď -> 'T', Ľ -> 'L', Ň -> 'N', Ķ -> 'K'). The ones
+ *   whose sound differs are:
+ *     Ć Ĉ Ċ Č             -> 'X'  ("ch")
+ *     Ŝ Ş Ș Ʃ             -> 'X', alternate 'S' for Ş and Ș ("sh")
+ *     Ś                   -> 'S', alternate 'X' (polish "sh")
+ *     Ź Ż Ƶ Ȥ             -> 'S'  (as Ž)
+ *     Ĝ Ġ Ģ Ǵ Ĵ Ɉ Ʒ Ǯ Ǆ    -> 'J'
+ *     Ř                   -> 'R', alternate "RJ" (czech "rzh")
+ *     Ţ Ț                 -> "TS", alternate 'T' (romanian "ts")
+ *     Ǳ                   -> "TS"
+ *     Ł                   -> 'L'
+ *     Ğ Ɂ                 -> nothing (turkish soft 'g', glottal stop)
+ */
+
+/**
+ * Key characters a consonant outside A-Z is encoded to, with the
+ * same arguments as metaphAddExactApprox4.
+ */
+type latinKey struct {
+	mainExact, altExact, main, alt string
+}
+
+/** Same key in every mode. */
+func latinSame(key string) latinKey { return latinKey{key, key, key, key} }
+
+/** Voiced consonant: 'exact' if m.encodeExact, 'approx' otherwise. */
+func latinVoiced(exact, approx string) latinKey { return latinKey{exact, exact, approx, approx} }
+
+/** Consonant with an alternate pronunciation. */
+func latinAlt(main, alt string) latinKey { return latinKey{main, alt, main, alt} }
+
+/**
+ * Upper case consonants of Latin Extended-A and -B, and the keys
+ * they encode to. See the mapping above.
+ *
+ * The word is upper cased before it is encoded, so the title case
+ * digraphs (ǅ ǈ ǋ ǲ) never reach this table. ĸ, ŉ, ǰ and ȷ are
+ * lower case letters with no upper case form, which unicode.ToUpper
+ * leaves as they are, so they are listed here as they are.
+ */
+var latinConsonants = map[rune]latinKey{
+	// Latin Extended-A
+	'Ć': latinSame("X"), 'Ĉ': latinSame("X"), 'Ċ': latinSame("X"), 'Č': latinSame("X"),
+	'Ď': latinVoiced("D", "T"), 'Đ': latinVoiced("D", "T"),
+	'Ĝ': latinSame("J"), 'Ğ': latinSame(""), 'Ġ': latinSame("J"), 'Ģ': latinSame("J"),
+	'Ĥ': latinSame("H"), 'Ħ': latinSame("H"),
+	'Ĵ': latinSame("J"), 'Ķ': latinSame("K"), 'ĸ': latinSame("K"),
+	'Ĺ': latinSame("L"), 'Ļ': latinSame("L"), 'Ľ': latinSame("L"), 'Ŀ': latinSame("L"), 'Ł': latinSame("L"),
+	'Ń': latinSame("N"), 'Ņ': latinSame("N"), 'Ň': latinSame("N"), 'ŉ': latinSame("N"), 'Ŋ': latinSame("N"),
+	'Ŕ': latinSame("R"), 'Ŗ': latinSame("R"), 'Ř': latinAlt("R", "RJ"),
+	'Ś': latinAlt("S", "X"), 'Ŝ': latinSame("X"), 'Ş': latinAlt("X", "S"),
+	'Ţ': latinAlt("TS", "T"), 'Ť': latinSame("T"), 'Ŧ': latinSame("T"),
+	'Ź': latinSame("S"), 'Ż': latinSame("S"),
+
+	// Latin Extended-B
+	'Ɓ': latinVoiced("B", "P"), 'Ƃ': latinVoiced("B", "P"), 'Ƀ': latinVoiced("B", "P"),
+	'Ƈ': latinSame("K"), 'Ȼ': latinSame("K"),
+	'Ɖ': latinVoiced("D", "T"), 'Ɗ': latinVoiced("D", "T"), 'Ƌ': latinVoiced("D", "T"),
+	'Ƒ': latinSame("F"),
+	'Ɠ': latinVoiced("G", "K"), 'Ɣ': latinVoiced("G", "K"), 'Ǥ': latinVoiced("G", "K"), 'Ǧ': latinVoiced("G", "K"),
+	'Ƙ': latinSame("K"), 'Ǩ': latinSame("K"), 'Ɋ': latinSame("K"),
+	'Ɲ': latinSame("N"), 'Ƞ': latinSame("N"), 'Ǹ': latinSame("N"), 'Ǌ': latinSame("N"),
+	'Ǉ': latinSame("L"), 'Ƚ': latinSame("L"),
+	'Ƥ': latinSame("P"),
+	'Ʀ': latinSame("R"), 'Ȑ': latinSame("R"), 'Ȓ': latinSame("R"), 'Ɍ': latinSame("R"),
+	'Ʃ': latinSame("X"), 'Ș': latinAlt("X", "S"),
+	'Ƭ': latinSame("T"), 'Ʈ': latinSame("T"), 'Ⱦ': latinSame("T"), 'Ț': latinAlt("TS", "T"),
+	'Ʋ': latinVoiced("V", "F"),
+	'Ƶ': latinSame("S"), 'Ȥ': latinSame("S"), 'Ȿ': latinSame("S"), 'Ɀ': latinSame("S"),
+	'Ʒ': latinSame("J"), 'Ƹ': latinSame("J"), 'Ǯ': latinSame("J"), 'Ǵ': latinSame("J"), 'Ɉ': latinSame("J"), 'ǰ': latinSame("J"), 'ȷ': latinSame("J"),
+	'Ǆ': latinSame("J"), 'Ǳ': latinSame("TS"),
+	'Ƕ': latinSame("H"), 'Ȟ': latinSame("H"),
+	'Ɂ': latinSame(""),
+}
+
+/**
+ * Tests for vowels of Latin Extended-A and -B, other than
+ * 'Œ' and 'Ÿ' which isVowel tests itself.
+ *
+ */
+func isLatinExtendedVowel(inChar rune) bool {
+	switch inChar {
+	case 'Ā', 'Ă', 'Ą', 'Ē', 'Ĕ', 'Ė', 'Ę', 'Ě', 'Ĩ', 'Ī', 'Ĭ', 'Į', 'İ',
+		'Ĳ', 'Ō', 'Ŏ', 'Ő', 'Ũ', 'Ū', 'Ŭ', 'Ů', 'Ű', 'Ų', 'Ŵ', 'Ŷ',
+		'Ɔ', 'Ǝ', 'Ə', 'Ɛ', 'Ɩ', 'Ɨ', 'Ɯ', 'Ɵ', 'Ơ', 'Ư', 'Ʊ', 'Ƴ',
+		'Ǎ', 'Ǐ', 'Ǒ', 'Ǔ', 'Ǖ', 'Ǘ', 'Ǚ', 'Ǜ', 'Ǟ', 'Ǡ', 'Ǣ', 'Ǫ', 'Ǭ',
+		'Ǻ', 'Ǽ', 'Ǿ', 'Ȁ', 'Ȃ', 'Ȅ', 'Ȇ', 'Ȉ', 'Ȋ', 'Ȍ', 'Ȏ', 'Ȕ', 'Ȗ',
+		'Ȝ', 'Ȣ', 'Ȧ', 'Ȩ', 'Ȫ', 'Ȭ', 'Ȯ', 'Ȱ', 'Ȳ', 'Ⱥ', 'Ʉ', 'Ʌ', 'Ɇ', 'Ɏ':
+		return true
+	}
+	return false
+}
+
+/**
+ * Tests for close front vowels - 'E', 'I', 'Y' and their
+ * accented forms.
+ *
+ */
+func isFrontVowel(inChar rune) bool {
+	switch inChar {
+	case 'E', 'I', 'Y',
+		'È', 'É', 'Ê', 'Ë', 'Ì', 'Í', 'Î', 'Ï', 'Ý',
+		'Ē', 'Ĕ', 'Ė', 'Ę', 'Ě', 'Ĩ', 'Ī', 'Ĭ', 'Į', 'İ', 'Ĳ', 'Ŷ', 'Ÿ',
+		'Ǝ', 'Ɛ', 'Ɨ', 'Ƴ', 'Ǐ', 'Ȅ', 'Ȇ', 'Ȉ', 'Ȋ', 'Ȩ', 'Ȳ', 'Ɇ', 'Ɏ':
+		return true
+	}
+	return false
+}
+
+/**
+ * Encodes a consonant of Latin Extended-A or -B according
+ * to latinConsonants.
+ *
+ * @return true if encoding handled in this routine, false if not
+ *
+ */
+func (m *metaph) encode_Latin_Extended() bool {
+	key, ok := latinConsonants[m.charAt(m.current)]
+	if !ok {
+		return false
+	}
+
+	m.metaphAddExactApprox4(key.mainExact, key.altExact, key.main, key.alt)
+	m.current++
+	return true
+}
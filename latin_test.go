@@ -0,0 +1,358 @@
+package metaphone3
+
+import (
+	"testing"
+	"unicode"
+)
+
+/**
+ * Every consonant of latinConsonants, and the keys of the letter
+ * followed by 'A', approximate and exact.
+ */
+var latinConsonantKeys = []struct {
+	letter                       rune
+	primary, alternate           string
+	exactPrimary, exactAlternate string
+}{
+	{'Ć', "X", "", "X", ""},
+	{'Ĉ', "X", "", "X", ""},
+	{'Ċ', "X", "", "X", ""},
+	{'Č', "X", "", "X", ""},
+	{'Ď', "T", "", "D", ""},
+	{'Đ', "T", "", "D", ""},
+	{'Ĝ', "J", "", "J", ""},
+	{'Ğ', "", "", "", ""},
+	{'Ġ', "J", "", "J", ""},
+	{'Ģ', "J", "", "J", ""},
+	{'Ĥ', "H", "", "H", ""},
+	{'Ħ', "H", "", "H", ""},
+	{'Ĵ', "J", "", "J", ""},
+	{'Ķ', "K", "", "K", ""},
+	{'ĸ', "K", "", "K", ""},
+	{'Ĺ', "L", "", "L", ""},
+	{'Ļ', "L", "", "L", ""},
+	{'Ľ', "L", "", "L", ""},
+	{'Ŀ', "L", "", "L", ""},
+	{'Ł', "L", "", "L", ""},
+	{'Ń', "N", "", "N", ""},
+	{'Ņ', "N", "", "N", ""},
+	{'Ň', "N", "", "N", ""},
+	{'ŉ', "N", "", "N", ""},
+	{'Ŋ', "N", "", "N", ""},
+	{'Ŕ', "R", "", "R", ""},
+	{'Ŗ', "R", "", "R", ""},
+	{'Ř', "R", "RJ", "R", "RJ"},
+	{'Ś', "S", "X", "S", "X"},
+	{'Ŝ', "X", "", "X", ""},
+	{'Ş', "X", "S", "X", "S"},
+	{'Ţ', "TS", "T", "TS", "T"},
+	{'Ť', "T", "", "T", ""},
+	{'Ŧ', "T", "", "T", ""},
+	{'Ź', "S", "", "S", ""},
+	{'Ż', "S", "", "S", ""},
+	{'Ɓ', "P", "", "B", ""},
+	{'Ƃ', "P", "", "B", ""},
+	{'Ƈ', "K", "", "K", ""},
+	{'Ɖ', "T", "", "D", ""},
+	{'Ɗ', "T", "", "D", ""},
+	{'Ƌ', "T", "", "D", ""},
+	{'Ƒ', "F", "", "F", ""},
+	{'Ɠ', "K", "", "G", ""},
+	{'Ɣ', "K", "", "G", ""},
+	{'Ƙ', "K", "", "K", ""},
+	{'Ɲ', "N", "", "N", ""},
+	{'Ƥ', "P", "", "P", ""},
+	{'Ʀ', "R", "", "R", ""},
+	{'Ʃ', "X", "", "X", ""},
+	{'Ƭ', "T", "", "T", ""},
+	{'Ʈ', "T", "", "T", ""},
+	{'Ʋ', "F", "", "V", ""},
+	{'Ƶ', "S", "", "S", ""},
+	{'Ʒ', "J", "", "J", ""},
+	{'Ƹ', "J", "", "J", ""},
+	{'Ǆ', "J", "", "J", ""},
+	{'Ǉ', "L", "", "L", ""},
+	{'Ǌ', "N", "", "N", ""},
+	{'Ǥ', "K", "", "G", ""},
+	{'Ǧ', "K", "", "G", ""},
+	{'Ǩ', "K", "", "K", ""},
+	{'Ǯ', "J", "", "J", ""},
+	{'ǰ', "J", "", "J", ""},
+	{'Ǳ', "TS", "", "TS", ""},
+	{'Ǵ', "J", "", "J", ""},
+	{'Ƕ', "H", "", "H", ""},
+	{'Ǹ', "N", "", "N", ""},
+	{'Ȑ', "R", "", "R", ""},
+	{'Ȓ', "R", "", "R", ""},
+	{'Ș', "X", "S", "X", "S"},
+	{'Ț', "TS", "T", "TS", "T"},
+	{'Ȟ', "H", "", "H", ""},
+	{'Ƞ', "N", "", "N", ""},
+	{'Ȥ', "S", "", "S", ""},
+	{'ȷ', "J", "", "J", ""},
+	{'Ȼ', "K", "", "K", ""},
+	{'Ƚ', "L", "", "L", ""},
+	{'Ⱦ', "T", "", "T", ""},
+	{'Ɂ', "", "", "", ""},
+	{'Ƀ', "P", "", "B", ""},
+	{'Ɉ', "J", "", "J", ""},
+	{'Ɋ', "K", "", "K", ""},
+	{'Ɍ', "R", "", "R", ""},
+	{'Ȿ', "S", "", "S", ""},
+	{'Ɀ', "S", "", "S", ""},
+}
+
+func TestLatinConsonants(t *testing.T) {
+	m := New()
+	exact := New()
+	exact.SetEncodeExact(true)
+
+	seen := make(map[rune]bool)
+	for _, c := range latinConsonantKeys {
+		seen[c.letter] = true
+
+		letters := []rune{c.letter}
+		if lower := unicode.ToLower(c.letter); lower != c.letter {
+			letters = append(letters, lower)
+		}
+		for _, letter := range letters {
+			word := string(letter) + "A"
+			if p, a := m.Encode(word); p != c.primary || a != c.alternate {
+				t.Errorf("Encode(%q) = %q, %q, want %q, %q", word, p, a, c.primary, c.alternate)
+			}
+			if p, a := exact.Encode(word); p != c.exactPrimary || a != c.exactAlternate {
+				t.Errorf("exact Encode(%q) = %q, %q, want %q, %q", word, p, a, c.exactPrimary, c.exactAlternate)
+			}
+		}
+	}
+
+	for letter := range latinConsonants {
+		if !seen[letter] {
+			t.Errorf("no test for %q", letter)
+		}
+		// the word is upper cased first, so a letter that
+		// changes would never be looked up
+		if upper := unicode.ToUpper(letter); upper != letter {
+			t.Errorf("%q is upper cased to %q before it is encoded", letter, upper)
+		}
+	}
+
+	// ĸ, ŉ, ǰ and ȷ have no upper case form, and the
+	// title case digraphs encode as their upper case form
+	for _, word := range [][2]string{{"ĸA", "KA"}, {"ŉA", "NA"}, {"ǰA", "JA"}, {"ȷA", "JA"}, {"ǅA", "ǄA"}, {"ǈA", "ǇA"}, {"ǋA", "ǊA"}, {"ǲA", "ǱA"}} {
+		p, a := m.Encode(word[0])
+		if wp, wa := m.Encode(word[1]); p != wp || a != wa {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q as for %q", word[0], p, a, wp, wa, word[1])
+		}
+	}
+}
+
+/**
+ * Every vowel outside A-Z, and whether it is a front vowel,
+ * which softens a preceding 'C' ("CET" -> "ST", "CAT" -> "KT").
+ */
+var latinVowels = []struct {
+	letter rune
+	front  bool
+}{
+	{'À', false},
+	{'Á', false},
+	{'Â', false},
+	{'Ã', false},
+	{'Ä', false},
+	{'Å', false},
+	{'Æ', false},
+	{'È', true},
+	{'É', true},
+	{'Ê', true},
+	{'Ë', true},
+	{'Ì', true},
+	{'Í', true},
+	{'Î', true},
+	{'Ï', true},
+	{'Ò', false},
+	{'Ó', false},
+	{'Ô', false},
+	{'Õ', false},
+	{'Ö', false},
+	{'Ø', false},
+	{'Ù', false},
+	{'Ú', false},
+	{'Û', false},
+	{'Ü', false},
+	{'Ý', true},
+	{'Ā', false},
+	{'Ă', false},
+	{'Ą', false},
+	{'Ē', true},
+	{'Ĕ', true},
+	{'Ė', true},
+	{'Ę', true},
+	{'Ě', true},
+	{'Ĩ', true},
+	{'Ī', true},
+	{'Ĭ', true},
+	{'Į', true},
+	{'İ', true},
+	{'Ĳ', true},
+	{'Ō', false},
+	{'Ŏ', false},
+	{'Ő', false},
+	{'Œ', false},
+	{'Ũ', false},
+	{'Ū', false},
+	{'Ŭ', false},
+	{'Ů', false},
+	{'Ű', false},
+	{'Ų', false},
+	{'Ŵ', false},
+	{'Ŷ', true},
+	{'Ÿ', true},
+	{'Ɔ', false},
+	{'Ǝ', true},
+	{'Ə', false},
+	{'Ɛ', true},
+	{'Ɩ', false},
+	{'Ɨ', true},
+	{'Ɯ', false},
+	{'Ɵ', false},
+	{'Ơ', false},
+	{'Ư', false},
+	{'Ʊ', false},
+	{'Ƴ', true},
+	{'Ǎ', false},
+	{'Ǐ', true},
+	{'Ǒ', false},
+	{'Ǔ', false},
+	{'Ǖ', false},
+	{'Ǘ', false},
+	{'Ǚ', false},
+	{'Ǜ', false},
+	{'Ǟ', false},
+	{'Ǡ', false},
+	{'Ǣ', false},
+	{'Ǫ', false},
+	{'Ǭ', false},
+	{'Ǻ', false},
+	{'Ǽ', false},
+	{'Ǿ', false},
+	{'Ȁ', false},
+	{'Ȃ', false},
+	{'Ȅ', true},
+	{'Ȇ', true},
+	{'Ȉ', true},
+	{'Ȋ', true},
+	{'Ȍ', false},
+	{'Ȏ', false},
+	{'Ȕ', false},
+	{'Ȗ', false},
+	{'Ȝ', false},
+	{'Ȣ', false},
+	{'Ȧ', false},
+	{'Ȩ', true},
+	{'Ȫ', false},
+	{'Ȭ', false},
+	{'Ȯ', false},
+	{'Ȱ', false},
+	{'Ȳ', true},
+	{'Ⱥ', false},
+	{'Ʉ', false},
+	{'Ʌ', false},
+	{'Ɇ', true},
+	{'Ɏ', true},
+}
+
+func TestLatinVowels(t *testing.T) {
+	m := New()
+	vowels := New()
+	vowels.SetEncodeVowels(true)
+
+	seen := make(map[rune]bool)
+	for _, c := range latinVowels {
+		seen[c.letter] = true
+
+		if !isVowel(c.letter) {
+			t.Errorf("isVowel(%q) = false", c.letter)
+		}
+		if isFrontVowel(c.letter) != c.front {
+			t.Errorf("isFrontVowel(%q) = %v", c.letter, !c.front)
+		}
+
+		want := "KT"
+		if c.front {
+			want = "ST"
+		}
+		letters := []rune{c.letter}
+		if lower := unicode.ToLower(c.letter); lower != c.letter {
+			letters = append(letters, lower)
+		}
+		for _, letter := range letters {
+			if p, _ := m.Encode("C" + string(letter) + "T"); p != want {
+				t.Errorf("Encode(%q) = %q, want %q", "C"+string(letter)+"T", p, want)
+			}
+			if p, _ := vowels.Encode(string(letter)); p != "A" {
+				t.Errorf("vowels Encode(%q) = %q, want %q", string(letter), p, "A")
+			}
+		}
+	}
+
+	for r := rune(0x80); r < 0x250; r++ {
+		if isVowel(r) && !seen[r] {
+			t.Errorf("no test for %q", r)
+		}
+	}
+}
+
+/**
+ * The Latin-1 letters the main loop handles itself. In the Java
+ * reference 'ß' falls through to 'Ç' and 'Ð' to 'Þ', so each pair
+ * must encode alike; 'Š', 'Ž', 'Œ' and 'Ÿ' were corrupted literals
+ * in earlier versions of the port.
+ */
+var latin1Letters = []struct {
+	word                         string
+	primary, alternate           string
+	exactPrimary, exactAlternate string
+	vowelsPrimary                string
+}{
+	{"AßA", "AS", "", "AS", "", "ASA"},
+	{"AÇA", "AS", "", "AS", "", "ASA"},
+	{"AÑA", "AN", "", "AN", "", "ANA"},
+	{"AÐA", "A0", "", "A0", "", "A0A"},
+	{"AÞA", "A0", "", "A0", "", "A0A"},
+	{"AŠA", "AX", "", "AX", "", "AXA"},
+	{"AŽA", "AS", "", "AS", "", "ASA"},
+	{"ŒA", "A", "", "A", "", "A"},
+	{"ŸA", "A", "", "A", "", "A"},
+	{"KŒN", "KN", "", "KN", "", "KAN"},
+	{"KŸN", "KN", "", "KN", "", "KAN"},
+	{"aßa", "AS", "", "AS", "", "ASA"},
+	{"aça", "AS", "", "AS", "", "ASA"},
+	{"aña", "AN", "", "AN", "", "ANA"},
+	{"aða", "A0", "", "A0", "", "A0A"},
+	{"aþa", "A0", "", "A0", "", "A0A"},
+	{"aša", "AX", "", "AX", "", "AXA"},
+	{"aža", "AS", "", "AS", "", "ASA"},
+	{"kœn", "KN", "", "KN", "", "KAN"},
+	{"kÿn", "KN", "", "KN", "", "KAN"},
+}
+
+func TestLatin1Letters(t *testing.T) {
+	m := New()
+	exact := New()
+	exact.SetEncodeExact(true)
+	vowels := New()
+	vowels.SetEncodeVowels(true)
+
+	for _, c := range latin1Letters {
+		if p, a := m.Encode(c.word); p != c.primary || a != c.alternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q", c.word, p, a, c.primary, c.alternate)
+		}
+		if p, a := exact.Encode(c.word); p != c.exactPrimary || a != c.exactAlternate {
+			t.Errorf("exact Encode(%q) = %q, %q, want %q, %q", c.word, p, a, c.exactPrimary, c.exactAlternate)
+		}
+		if p, _ := vowels.Encode(c.word); p != c.vowelsPrimary {
+			t.Errorf("vowels Encode(%q) = %q, want %q", c.word, p, c.vowelsPrimary)
+		}
+	}
+}
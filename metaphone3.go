@@ -176,16 +176,68 @@ Translated by Andy Jackson.
 package metaphone3
 
 import (
-	"strings"
+	"bytes"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
-/** Default size of key storage allocation */
-var MAX_KEY_ALLOCATION = 32
+/** Default size of key storage allocation. Per-encoder
+ * limits are set with Config.MaxKeyLength. */
+const MAX_KEY_ALLOCATION = 32
 
-/** Default maximum length of encoded key. */
-var DEFAULT_MAX_KEY_LENGTH = 8
+/** Default maximum length of encoded key. Per-encoder
+ * lengths are set with Config.KeyLength. */
+const DEFAULT_MAX_KEY_LENGTH = 8
 
+/** Every character a key can contain: '0' for "TH", 'A' for
+ * vowels, and the consonant keys. 'B', 'D', 'G' and 'V' only
+ * appear when encoding exact. */
+const KEY_ALPHABET = "0AFHJKLMNPRSTXBDGV"
+
+/**
+ * M3 holds the encoding settings of a Metaphone 3 encoder. Once configured,
+ * an M3 may be shared by any number of goroutines: Encode keeps its working
+ * state in a per-call metaph value taken from an internal pool, so concurrent
+ * calls never touch each other's keys. The Set* methods are not synchronized
+ * and must not be called while other goroutines are encoding.
+ */
 type M3 struct {
+	/** Encoding settings: vowels, exact and key lengths. */
+	config Config
+
+	/** Custom normalizer replacing config.Normalization, if set. */
+	normalizer Normalizer
+
+	/** Exception sets used by the rules. */
+	exceptions Exceptions
+
+	/** Whole words whose keys are known, if set. */
+	overrides *Overrides
+
+	/** Entries of overrides normalized like the input, if set. */
+	overrideIndex *overrideIndex
+
+	/** Custom rules tried before the built-in handlers, if set. */
+	rules *RuleSet
+
+	/** Rules registered with WithRule, by letter. */
+	hooks map[rune][]Rule
+
+	/** Config.Customization, worked out by settle. */
+	customized string
+
+	/** Fingerprint of the keys, worked out by settle. */
+	fingerprint Fingerprint
+}
+
+/**
+ * Working state of a single encoding. A metaph is taken
+ * from metaphPool for each call to Encode, loaded with
+ * the settings of the calling M3, and returned to the
+ * pool when the keys have been copied out.
+ */
+type metaph struct {
 	/** Flag whether or not to encode non-initial vowels. */
 	encodeVowels bool
 
@@ -197,11 +249,13 @@ type M3 struct {
 	* measured at beginning of encoding. */
 	length int
 
-	/** Running copy of primary key. */
-	primary strings.Builder
+	/** Running copy of primary key. Reused from one
+	* encoding to the next. */
+	primary []byte
 
-	/** Running copy of secondary key. */
-	secondary strings.Builder
+	/** Running copy of secondary key. Reused from one
+	* encoding to the next. */
+	secondary []byte
 
 	/** Index of character in m.inWord currently being
 	* encoded. */
@@ -216,9 +270,97 @@ type M3 struct {
 	/** Length of encoded key string. */
 	metaphLength int
 
-	/** Internal copy of word to be encoded, allocated separately
-	* from pointed to in incoming parameter string. */
-	inWord string
+	/** Internal upper cased copy of word to be encoded, one
+	* rune per character so that it can be indexed directly.
+	* Reused from one encoding to the next. */
+	inWord []rune
+
+	/** Custom normalizer, if any. See WithNormalizer. */
+	normalizer Normalizer
+
+	/** Built-in normalization, used if normalizer is nil. */
+	normalization Normalization
+
+	/** Runes of the word as passed in, before normalization.
+	* Only filled in when normalizing. */
+	raw []rune
+
+	/** offsets[i] is the index in m.raw that m.inWord[i] came
+	* from. Empty when not normalizing. */
+	offsets []int
+
+	/** Flag whether or not to record a trace of the rules that fire. */
+	trace bool
+
+	/** Trace recorded when trace is set. See Explain. */
+	steps []Step
+
+	/** Value of m.current when the main loop iteration
+	* being traced began. */
+	stepStart int
+
+	/** Index in m.steps of the first step of the main loop
+	* iteration being traced. */
+	stepFirst int
+
+	/** Flag whether or not to record every metaphAdd in m.choices. */
+	expand bool
+
+	/** Exception sets of the encoder. See WithExceptions. */
+	exceptions Exceptions
+
+	/** Override table of the encoder, if any. See WithOverrides. */
+	overrides *Overrides
+
+	/** Index of overrides for a normalizing encoder. */
+	overrideIndex *overrideIndex
+
+	/** UTF-8 copy of m.inWord, for looking it up in overrides. */
+	wordBuf []byte
+
+	/** Whether m.inWord has been looked up in overrides since
+	 * setWord, and the result. */
+	overrideLooked, overrideFound bool
+
+	/** Keys found in overrides, if overrideFound. */
+	override Override
+
+	/** Custom rules of the encoder, if any. See WithRules. */
+	rules *RuleSet
+
+	/** Rules of the encoder by letter. See WithRule. */
+	hooks map[rune][]Rule
+
+	/** Name of the custom rule being applied, for the trace. */
+	ruleName string
+
+	/** Encodings appended so far, recorded when expand is set.
+	* See EncodeAll. */
+	choices []choice
+}
+
+/** Pool of working states shared by all encoders. */
+var metaphPool = sync.Pool{
+	New: func() interface{} { return new(metaph) },
+}
+
+/**
+ * Takes a working state from the pool and loads the encoder's
+ * settings into it. Give it back with metaphPool.Put.
+ */
+func (m *M3) getMetaph() *metaph {
+	s := metaphPool.Get().(*metaph)
+	s.encodeVowels = m.config.EncodeVowels
+	s.encodeExact = m.config.EncodeExact
+	s.metaphLength = m.config.KeyLength
+	s.normalizer = m.normalizer
+	s.normalization = m.config.Normalization
+	s.exceptions = m.exceptions
+	s.overrides = m.overrides
+	s.overrideIndex = m.overrideIndex
+	s.rules = m.rules
+	s.hooks = m.hooks
+	return s
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -232,15 +374,18 @@ type M3 struct {
  *
  */
 func New() *M3 {
-	return &M3{
-		metaphLength: DEFAULT_MAX_KEY_LENGTH,
+	m := &M3{
+		config:     DefaultConfig(),
+		exceptions: DefaultExceptions(),
 	}
+	m.settle()
+	return m
 }
 
 /**
  * Sets length allocated for output keys.
  * If incoming number is greater than maximum allowable
- * length returned by GetMaximumKeyLength(), set key length
+ * length in Config().MaxKeyLength, set key length
  * to maximum key length and return false;  otherwise, set key
  * length to parameter value and return true.
  *
@@ -255,12 +400,14 @@ func (m *M3) SetKeyLength(inKeyLength int) bool {
 		inKeyLength = 1
 	}
 
-	if inKeyLength > MAX_KEY_ALLOCATION {
-		m.metaphLength = MAX_KEY_ALLOCATION
+	defer m.settle()
+
+	if inKeyLength > m.config.MaxKeyLength {
+		m.config.KeyLength = m.config.MaxKeyLength
 		return false
 	}
 
-	m.metaphLength = inKeyLength
+	m.config.KeyLength = inKeyLength
 	return true
 }
 
@@ -271,16 +418,26 @@ func (m *M3) SetKeyLength(inKeyLength int) bool {
  * @param alt alternative encoding character to be added to encoded alternative key string
  *
  */
-func (m *M3) metaphAdd(main string, alt string) {
-	if !(main == "A" && (m.primary.Len() > 0) && (m.primary.String()[m.primary.Len()-1] == 'A')) {
-		m.primary.WriteString(main)
+func (m *metaph) metaphAdd(main string, alt string) {
+	primaryLen, secondaryLen := len(m.primary), len(m.secondary)
+
+	if !(main == "A" && (len(m.primary) > 0) && (m.primary[len(m.primary)-1] == 'A')) {
+		m.primary = append(m.primary, main...)
 	}
 
-	if !(alt == "A" && (m.secondary.Len() > 0) && (m.secondary.String()[m.secondary.Len()-1] == 'A')) {
+	if !(alt == "A" && (len(m.secondary) > 0) && (m.secondary[len(m.secondary)-1] == 'A')) {
 		if alt != "" {
-			m.secondary.WriteString(alt)
+			m.secondary = append(m.secondary, alt...)
 		}
 	}
+
+	if m.expand {
+		m.choices = append(m.choices, choice{main, alt})
+	}
+
+	if m.trace {
+		m.traceAdd(primaryLen, secondaryLen)
+	}
 }
 
 /**
@@ -297,7 +454,7 @@ func (m *M3) metaphAdd(main string, alt string) {
  * @param alt alternative encoding character to be added to encoded alternative key string
  *
  */
-func (m *M3) metaphAddExactApprox4(mainExact string, altExact string, main string, alt string) {
+func (m *metaph) metaphAddExactApprox4(mainExact string, altExact string, main string, alt string) {
 	if m.encodeExact {
 		m.metaphAdd(mainExact, altExact)
 	} else {
@@ -314,7 +471,7 @@ func (m *M3) metaphAddExactApprox4(mainExact string, altExact string, main strin
  * @param main primary encoding character to be added to encoded key string
  *
  */
-func (m *M3) metaphAddExactApprox(mainExact string, main string) {
+func (m *metaph) metaphAddExactApprox(mainExact string, main string) {
 	if m.encodeExact {
 		m.metaphAdd(mainExact, mainExact)
 	} else {
@@ -329,7 +486,10 @@ func (m *M3) metaphAddExactApprox(mainExact string, main string) {
  *
  * @param inEncodeVowels Non-initial vowels encoded if true, not if false.
  */
-func (m *M3) SetEncodeVowels(inEncodeVowels bool) { m.encodeVowels = inEncodeVowels }
+func (m *M3) SetEncodeVowels(inEncodeVowels bool) {
+	m.config.EncodeVowels = inEncodeVowels
+	m.settle()
+}
 
 /** Sets flag that causes Metaphone3 to encode consonants as exactly as possible.
  * This does not include 'S' vs. 'Z', since americans will pronounce 'S' at the
@@ -339,15 +499,18 @@ func (m *M3) SetEncodeVowels(inEncodeVowels bool) { m.encodeVowels = inEncodeVow
  *
  * @param inEncodeExact consonants to be encoded "exactly" if true, not if false.
  */
-func (m *M3) SetEncodeExact(inEncodeExact bool) { m.encodeExact = inEncodeExact }
+func (m *M3) SetEncodeExact(inEncodeExact bool) {
+	m.config.EncodeExact = inEncodeExact
+	m.settle()
+}
 
 /**
  * Test for close front vowels
  *
  * @return true if close front vowel
  */
-func (m *M3) front_Vowel(at int) bool {
-	return ((m.charAt(at) == 'E') || (m.charAt(at) == 'I') || (m.charAt(at) == 'Y'))
+func (m *metaph) front_Vowel(at int) bool {
+	return isFrontVowel(m.charAt(at))
 }
 
 /**
@@ -356,7 +519,7 @@ func (m *M3) front_Vowel(at int) bool {
  * of choosing alternate pronunciations correctly
  *
  */
-func (m *M3) slavoGermanic() bool {
+func (m *metaph) slavoGermanic() bool {
 	return m.stringAt(0, 3, "SCH", "") || m.stringAt(0, 2, "SW", "") || (m.charAt(0) == 'J') || (m.charAt(0) == 'W')
 }
 
@@ -368,7 +531,7 @@ func (m *M3) slavoGermanic() bool {
  *
  */
 func isVowel(inChar rune) bool {
-	return (inChar == 'A') || (inChar == 'E') || (inChar == 'I') || (inChar == 'O') || (inChar == 'U') || (inChar == 'Y') || (inChar == 'À') || (inChar == 'Á') || (inChar == 'Â') || (inChar == 'Ã') || (inChar == 'Ä') || (inChar == 'Å') || (inChar == 'Æ') || (inChar == 'È') || (inChar == 'É') || (inChar == 'Ê') || (inChar == 'Ë') || (inChar == 'Ì') || (inChar == 'Í') || (inChar == 'Î') || (inChar == 'Ï') || (inChar == 'Ò') || (inChar == 'Ó') || (inChar == 'Ô') || (inChar == 'Õ') || (inChar == 'Ö') || (inChar == '') || (inChar == 'Ø') || (inChar == 'Ù') || (inChar == 'Ú') || (inChar == 'Û') || (inChar == 'Ü') || (inChar == 'Ý') || (inChar == '')
+	return (inChar == 'A') || (inChar == 'E') || (inChar == 'I') || (inChar == 'O') || (inChar == 'U') || (inChar == 'Y') || (inChar == 'À') || (inChar == 'Á') || (inChar == 'Â') || (inChar == 'Ã') || (inChar == 'Ä') || (inChar == 'Å') || (inChar == 'Æ') || (inChar == 'È') || (inChar == 'É') || (inChar == 'Ê') || (inChar == 'Ë') || (inChar == 'Ì') || (inChar == 'Í') || (inChar == 'Î') || (inChar == 'Ï') || (inChar == 'Ò') || (inChar == 'Ó') || (inChar == 'Ô') || (inChar == 'Õ') || (inChar == 'Ö') || (inChar == 'Œ') || (inChar == 'Ø') || (inChar == 'Ù') || (inChar == 'Ú') || (inChar == 'Û') || (inChar == 'Ü') || (inChar == 'Ý') || (inChar == 'Ÿ') || ((inChar > 'ÿ') && isLatinExtendedVowel(inChar))
 }
 
 /**
@@ -379,7 +542,7 @@ func isVowel(inChar rune) bool {
  *
  * @return position of next consonant in to be encoded string
  */
-func (m *M3) skipVowels(at int) int {
+func (m *metaph) skipVowels(at int) int {
 	if at < 0 {
 		return 0
 	}
@@ -415,7 +578,7 @@ func (m *M3) skipVowels(at int) int {
  * @param ifEncodeVowels number of characters to advance if encoding internal vowels
  *
  */
-func (m *M3) advanceCounter(ifNotEncodeVowels, ifEncodeVowels int) {
+func (m *metaph) advanceCounter(ifNotEncodeVowels, ifEncodeVowels int) {
 	if !m.encodeVowels {
 		m.current += ifNotEncodeVowels
 	} else {
@@ -429,18 +592,13 @@ func (m *M3) advanceCounter(ifNotEncodeVowels, ifEncodeVowels int) {
 	 * @param at index of character to access
 	 * @return null if index out of bounds, .charAt() otherwise
 */
-func (m *M3) charAt(at int) rune {
+func (m *metaph) charAt(at int) rune {
 	// check subbounds string
 	if (at < 0) || (at > (m.length - 1)) {
 		return rune(0)
 	}
 
-	for i, r := range m.inWord {
-		if i == at {
-			return r
-		}
-	}
-	return rune(0)
+	return m.inWord[at]
 }
 
 /**
@@ -450,54 +608,77 @@ func (m *M3) charAt(at int) rune {
  * inflected forms, and not completely different words which may have the
  * same subin them string.
  */
-func rootOrInflections(inWord string, root string) bool {
-	var test string
+func rootOrInflections(inWord []rune, root string) bool {
+	if root == "" {
+		return false
+	}
 
-	test = root + "S"
-	if (inWord == root) || (inWord == test) {
+	if wordIs(inWord, root, "") || wordIs(inWord, root, "S") {
 		return true
 	}
 
-	if root[len(root)-1] != 'E' {
-		test = root + "ES"
-	}
+	endsInE := root[len(root)-1] == 'E'
 
-	if inWord == test {
+	if !endsInE && wordIs(inWord, root, "ES") {
 		return true
 	}
 
-	if root[len(root)-1] != 'E' {
-		test = root + "ED"
-	} else {
-		test = root + "D"
-	}
-
-	if inWord == test {
+	if !endsInE {
+		if wordIs(inWord, root, "ED") {
+			return true
+		}
+	} else if wordIs(inWord, root, "D") {
 		return true
 	}
 
-	if root[len(root)-1] == 'E' {
+	if endsInE {
 		root = root[:len(root)-1]
 	}
 
-	test = root + "ING"
-	if inWord == test {
+	if wordIs(inWord, root, "ING") {
 		return true
 	}
 
-	test = root + "INGLY"
-	if inWord == test {
+	if wordIs(inWord, root, "INGLY") {
 		return true
 	}
 
-	test = root + "Y"
-	if inWord == test {
+	if wordIs(inWord, root, "Y") {
 		return true
 	}
 
 	return false
 }
 
+/**
+ * Tests whether the word is exactly root followed by suffix,
+ * without building the concatenated string.
+ */
+func wordIs(inWord []rune, root string, suffix string) bool {
+	n := runesPrefix(inWord, root)
+	if n < 0 {
+		return false
+	}
+	return runesPrefix(inWord[n:], suffix) == len(inWord)-n
+}
+
+/**
+ * Tests whether runes begins with s.
+ *
+ * @return number of runes of s matched, or -1 if runes does
+ * not begin with s
+ */
+func runesPrefix(runes []rune, s string) int {
+	i := 0
+	for _, r := range s {
+		if i >= len(runes) || runes[i] != r {
+			return -1
+		}
+		i++
+	}
+	return i
+}
+
 /**
  * Determines if one of the substrings sent in is the same as
  * what is at the specified position in the being encoded string.
@@ -507,25 +688,48 @@ func rootOrInflections(inWord string, root string) bool {
  * @param compareStrings
  * @return
  */
-func (m *M3) stringAt(start, length int, compareStrings ...string) bool {
+func (m *metaph) stringAt(start, length int, compareStrings ...string) bool {
 	// check subbounds string
 	if (start < 0) || (start > (m.length - 1)) || ((start + length - 1) > (m.length - 1)) {
 		return false
 	}
 
-	b := strings.Builder{}
-	for i, r := range m.inWord {
-		if i >= start {
-			b.WriteRune(r)
+	target := m.inWord[start : start+length]
+
+	for _, strFragment := range compareStrings {
+		// a string has at least as many bytes as runes
+		if len(strFragment) >= length && runesEqual(target, strFragment) {
+			return true
 		}
-		if i == start+length {
-			break
+	}
+	return false
+}
+
+/**
+ * Tests whether runes spells s. Compares bytes directly for
+ * as long as s is ASCII, which the literals in the rules are.
+ */
+func runesEqual(runes []rune, s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return runesPrefix(runes[i:], s[i:]) == len(runes)-i
+		}
+
+		if i >= len(runes) || runes[i] != rune(c) {
+			return false
 		}
 	}
-	target := b.String()
+	return len(s) == len(runes)
+}
 
-	for _, strFragment := range compareStrings {
-		if target == strFragment {
+/**
+ * Tests whether str appears anywhere in the being encoded string.
+ */
+func (m *metaph) contains(str string) bool {
+	length := utf8.RuneCountInString(str)
+	for at := 0; at < m.length; at++ {
+		if m.stringAt(at, length, str) {
 			return true
 		}
 	}
@@ -534,21 +738,196 @@ func (m *M3) stringAt(start, length int, compareStrings ...string) bool {
 
 /**
  * Encodes input to one or two key values string according to Metaphone 3 rules.
+ * Any string is accepted, including invalid UTF-8; the keys are at most
+ * Config.KeyLength long and use only characters of KEY_ALPHABET.
+ * Safe for concurrent use by multiple goroutines.
  *
  */
 func (m *M3) Encode(in string) (primary, secondary string) {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	return s.encode(in)
+}
+
+/**
+ * Encodes the UTF-8 encoded word like Encode, appending the primary
+ * key to dstPrimary and the secondary key (if any) to dstAlt. When
+ * the destination slices have enough capacity, no memory is
+ * allocated, so this is the call to use when keying large batches.
+ * Safe for concurrent use by multiple goroutines.
+ *
+ * @return the extended dstPrimary and dstAlt
+ */
+func (m *M3) AppendEncode(dstPrimary, dstAlt []byte, word []byte) ([]byte, []byte) {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	s.setWordBytes(word)
+	s.run()
+
+	primary, secondary := s.primary, s.secondary
+
+	//only give back m.metaphLength number of chars in m.metaph
+	if len(primary) > s.metaphLength {
+		primary = primary[:s.metaphLength]
+	}
+
+	if len(secondary) > s.metaphLength {
+		secondary = secondary[:s.metaphLength]
+	}
+
+	dstPrimary = append(dstPrimary, primary...)
+
+	// lose the second one if it is the same
+	if !bytes.Equal(primary, secondary) {
+		dstAlt = append(dstAlt, secondary...)
+	}
+
+	return dstPrimary, dstAlt
+}
+
+/**
+ * Runs the Metaphone 3 rules over in using the settings
+ * already loaded into m.
+ *
+ */
+func (m *metaph) encode(in string) (primary, secondary string) {
+	m.setWord(in)
+	m.run()
+
+	return truncateKeys(string(m.primary), string(m.secondary), m.metaphLength)
+}
+
+/**
+ * Loads the upper cased runes of in into m.inWord.
+ *
+ */
+func (m *metaph) setWord(in string) {
+	m.overrideLooked = false
+
+	if m.normalizing() {
+		m.raw = m.raw[:0]
+		for _, r := range in {
+			m.raw = append(m.raw, r)
+		}
+		m.normalize()
+		return
+	}
+
+	m.inWord = m.inWord[:0]
+	m.offsets = m.offsets[:0]
+	for _, r := range in {
+		m.inWord = append(m.inWord, unicode.ToUpper(r))
+	}
+}
+
+/**
+ * Loads the upper cased runes of the UTF-8 encoded in
+ * into m.inWord.
+ *
+ */
+func (m *metaph) setWordBytes(in []byte) {
+	m.overrideLooked = false
+
+	if m.normalizing() {
+		m.raw = m.raw[:0]
+		for len(in) > 0 {
+			r, size := utf8.DecodeRune(in)
+			m.raw = append(m.raw, r)
+			in = in[size:]
+		}
+		m.normalize()
+		return
+	}
+
+	m.inWord = m.inWord[:0]
+	m.offsets = m.offsets[:0]
+	for len(in) > 0 {
+		r, size := utf8.DecodeRune(in)
+		m.inWord = append(m.inWord, unicode.ToUpper(r))
+		in = in[size:]
+	}
+}
+
+/**
+ * Tests whether words pass through a normalizer before encoding.
+ *
+ */
+func (m *metaph) normalizing() bool {
+	return m.normalizer != nil || !m.normalization.IsZero()
+}
+
+/**
+ * Normalizes m.raw into m.inWord and upper cases the result.
+ *
+ */
+func (m *metaph) normalize() {
+	if m.normalizer != nil {
+		m.inWord, m.offsets = m.normalizer.Normalize(m.inWord[:0], m.offsets[:0], m.raw)
+	} else {
+		m.inWord, m.offsets = m.normalization.Normalize(m.inWord[:0], m.offsets[:0], m.raw)
+	}
+
+	for i, r := range m.inWord {
+		m.inWord[i] = unicode.ToUpper(r)
+	}
+}
+
+/**
+ * Returns word, an upper cased override entry, normalized as
+ * setWord normalizes the input, without touching the word
+ * being encoded.
+ *
+ */
+func (m *metaph) normalizeEntry(word string) string {
+	var normalized []rune
+	if m.normalizer != nil {
+		normalized, _ = m.normalizer.Normalize(nil, nil, []rune(word))
+	} else {
+		normalized, _ = m.normalization.Normalize(nil, nil, []rune(word))
+	}
+
+	for i, r := range normalized {
+		normalized[i] = unicode.ToUpper(r)
+	}
+	return string(normalized)
+}
+
+/**
+ * Maps an index in m.inWord back to a rune offset in the word
+ * as passed in.
+ *
+ * @param at index in m.inWord, up to and including m.length
+ * @return offset of the rune m.inWord[at] came from
+ */
+func (m *metaph) inputOffset(at int) int {
+	if len(m.offsets) == 0 {
+		return at
+	}
+
+	if at >= len(m.offsets) {
+		return len(m.raw)
+	}
+	return m.offsets[at]
+}
+
+/**
+ * Runs the main loop over m.inWord, leaving the keys in
+ * m.primary and m.secondary. Stops once either key is
+ * longer than m.metaphLength.
+ *
+ */
+func (m *metaph) run() {
 	m.flag_AL_inversion = false
 
 	m.current = 0
 
-	m.inWord = strings.ToUpper(in)
-	m.primary.Reset()
-	m.secondary.Reset()
+	m.primary = m.primary[:0]
+	m.secondary = m.secondary[:0]
+	m.choices = m.choices[:0]
 
-	m.length = 0
-	for _ = range in {
-		m.length++
-	}
+	m.length = len(m.inWord)
 	if m.length < 1 {
 		return
 	}
@@ -556,20 +935,43 @@ func (m *M3) Encode(in string) (primary, secondary string) {
 	//zero based index
 	m.last = m.length - 1
 
+	if m.overrides != nil {
+		if m.trace {
+			m.traceBegin()
+		}
+
+		if m.encode_Override() {
+			if m.trace {
+				m.traceEnd()
+			}
+			return
+		}
+	}
+
 	///////////main loop//////////////////////////
-	for !(m.primary.Len() > m.metaphLength) && !(m.secondary.Len() > m.metaphLength) {
+	for !(len(m.primary) > m.metaphLength) && !(len(m.secondary) > m.metaphLength) {
 		if m.current >= m.length {
 			break
 		}
 
+		if m.trace {
+			m.traceBegin()
+		}
+
+		start := m.current
+
+		if (m.hooks != nil && m.encode_Hooks()) || (m.rules != nil && m.encode_Rules()) {
+			m.endStep(start)
+			continue
+		}
+
 		switch m.charAt(m.current) {
 		case 'B':
 
 			m.encode_B()
 			break
 
-		case 'ß':
-		case 'Ç':
+		case 'ß', 'Ç':
 
 			m.metaphAdd("S", "S")
 			m.current++
@@ -656,8 +1058,8 @@ func (m *M3) Encode(in string) (primary, secondary string) {
 			m.encode_T()
 			break
 
-		case 'Ð': // eth
-		case 'Þ': // thorn
+		case 'Ð', // eth
+			'Þ': // thorn
 
 			m.metaphAdd("0", "0")
 			m.current++
@@ -678,13 +1080,13 @@ func (m *M3) Encode(in string) (primary, secondary string) {
 			m.encode_X()
 			break
 
-		case '':
+		case 'Š':
 
 			m.metaphAdd("X", "X")
 			m.current++
 			break
 
-		case '':
+		case 'Ž':
 
 			m.metaphAdd("S", "S")
 			m.current++
@@ -702,20 +1104,52 @@ func (m *M3) Encode(in string) (primary, secondary string) {
 				break
 			}
 
+			if m.encode_Latin_Extended() {
+				break
+			}
+
 			m.current++
 
 		}
+
+		m.endStep(start)
+	}
+
+}
+
+/**
+ * Finishes a main loop iteration that began at start.
+ *
+ */
+func (m *metaph) endStep(start int) {
+	// every rule must consume at least one letter, otherwise
+	// the loop would never end
+	if m.current <= start {
+		m.current = start + 1
 	}
 
-	primary, secondary = m.primary.String(), m.secondary.String()
+	if m.trace {
+		m.traceEnd()
+	}
+}
 
+/**
+ * Cuts finished keys down to the requested length.
+ *
+ * @param primary untruncated primary key
+ * @param secondary untruncated secondary key
+ * @param metaphLength length of key to give back
+ * @return the truncated keys; secondary is empty if it is
+ * the same as primary
+ */
+func truncateKeys(primary, secondary string, metaphLength int) (string, string) {
 	//only give back m.metaphLength number of chars in m.metaph
-	if len(primary) > m.metaphLength {
-		primary = primary[:m.metaphLength]
+	if len(primary) > metaphLength {
+		primary = primary[:metaphLength]
 	}
 
-	if len(secondary) > m.metaphLength {
-		secondary = secondary[:m.metaphLength]
+	if len(secondary) > metaphLength {
+		secondary = secondary[:metaphLength]
 	}
 
 	// it is possible for the two metaphs to be the same
@@ -734,7 +1168,7 @@ func (m *M3) Encode(in string) (primary, secondary string) {
  *
  *
  */
-func (m *M3) encode_Vowels() {
+func (m *metaph) encode_Vowels() {
 	if m.current == 0 {
 		// all init vowels map to 'A'
 		// as of Double Metaphone
@@ -773,7 +1207,7 @@ func (m *M3) encode_Vowels() {
  *
  *
  */
-func (m *M3) encode_E_Pronounced() {
+func (m *metaph) encode_E_Pronounced() {
 	// special cases with two pronunciations
 	// 'agape' 'lame' 'resume'
 	if (m.stringAt(0, 4, "LAME", "SAKE", "PATE", "") && (m.length == 4)) || (m.stringAt(0, 5, "AGAPE", "") && (m.length == 5)) || ((m.current == 5) && m.stringAt(0, 6, "RESUME", "")) {
@@ -812,7 +1246,7 @@ func (m *M3) encode_E_Pronounced() {
  * @return true if encoded as silent - no addition to m.metaph key
  *
  */
-func (m *M3) o_Silent() bool {
+func (m *metaph) o_Silent() bool {
 	// if "iron" at beginning or end of word and not "irony"
 	if (m.charAt(m.current) == 'O') && m.stringAt((m.current-2), 4, "IRON", "") {
 		if (m.stringAt(0, 4, "IRON", "") || (m.stringAt((m.current-2), 4, "IRON", "") && (m.last == (m.current + 1)))) && !m.stringAt((m.current-2), 6, "IRONIC", "") {
@@ -830,7 +1264,7 @@ func (m *M3) o_Silent() bool {
  * @return true if encoded as silent - no addition to m.metaph key
  *
  */
-func (m *M3) e_Silent() bool {
+func (m *metaph) e_Silent() bool {
 	if m.e_Pronounced_At_End() {
 		return false
 	}
@@ -863,22 +1297,14 @@ func (m *M3) e_Silent() bool {
  * @return true if 'E' at end is pronounced
  *
  */
-func (m *M3) e_Pronounced_At_End() bool {
+func (m *metaph) e_Pronounced_At_End() bool {
 	if (m.current == m.last) && (m.stringAt((m.current-6), 7, "STROPHE", "") ||
 		// if a vowel is before the 'E', vowel eater will have eaten it.
 		//otherwise, consonant + 'E' will need 'E' pronounced
 		(m.length == 2) || ((m.length == 3) && !isVowel(m.charAt(0))) ||
 		// these german name endings can be relied on to have the 'e' pronounced
 		(m.stringAt((m.last-2), 3, "BKE", "DKE", "FKE", "KKE", "LKE",
-			"NKE", "MKE", "PKE", "TKE", "VKE", "ZKE", "") && !m.stringAt(0, 5, "FINKE", "FUNKE", "") && !m.stringAt(0, 6, "FRANKE", "")) || m.stringAt((m.last-4), 5, "SCHKE", "") || (m.stringAt(0, 4, "ACME", "NIKE", "CAFE", "RENE", "LUPE", "JOSE", "ESME", "") && (m.length == 4)) || (m.stringAt(0, 5, "LETHE", "CADRE", "TILDE", "SIGNE", "POSSE", "LATTE", "ANIME", "DOLCE", "CROCE",
-		"ADOBE", "OUTRE", "JESSE", "JAIME", "JAFFE", "BENGE", "RUNGE",
-		"CHILE", "DESME", "CONDE", "URIBE", "LIBRE", "ANDRE", "") && (m.length == 5)) || (m.stringAt(0, 6, "HECATE", "PSYCHE", "DAPHNE", "PENSKE", "CLICHE", "RECIPE",
-		"TAMALE", "SESAME", "SIMILE", "FINALE", "KARATE", "RENATE", "SHANTE",
-		"OBERLE", "COYOTE", "KRESGE", "STONGE", "STANGE", "SWAYZE", "FUENTE",
-		"SALOME", "URRIBE", "") && (m.length == 6)) || (m.stringAt(0, 7, "ECHIDNE", "ARIADNE", "MEINEKE", "PORSCHE", "ANEMONE", "EPITOME",
-		"SYNCOPE", "SOUFFLE", "ATTACHE", "MACHETE", "KARAOKE", "BUKKAKE",
-		"VICENTE", "ELLERBE", "VERSACE", "") && (m.length == 7)) || (m.stringAt(0, 8, "PENELOPE", "CALLIOPE", "CHIPOTLE", "ANTIGONE", "KAMIKAZE", "EURIDICE",
-		"YOSEMITE", "FERRANTE", "") && (m.length == 8)) || (m.stringAt(0, 9, "HYPERBOLE", "GUACAMOLE", "XANTHIPPE", "") && (m.length == 9)) || (m.stringAt(0, 10, "SYNECDOCHE", "") && (m.length == 10))) {
+			"NKE", "MKE", "PKE", "TKE", "VKE", "ZKE", "") && !m.stringAt(0, 5, "FINKE", "FUNKE", "") && !m.stringAt(0, 6, "FRANKE", "")) || m.stringAt((m.last-4), 5, "SCHKE", "") || m.wordIn(m.exceptions.EPronouncedAtEnd)) {
 		return true
 	}
 
@@ -890,7 +1316,7 @@ func (m *M3) e_Pronounced_At_End() bool {
  * "firestone"
  *
  */
-func (m *M3) silent_Internal_E() bool {
+func (m *metaph) silent_Internal_E() bool {
 	// 'olesen' but not 'olen'	RAKE BLAKE
 	if (m.stringAt(0, 3, "OLE", "") && m.e_Silent_Suffix(3) && !m.e_Pronouncing_Suffix(3)) || (m.stringAt(0, 4, "BARE", "FIRE", "FORE", "GATE", "HAGE", "HAVE",
 		"HAZE", "HOLE", "CAPE", "HUSE", "LACE", "LINE",
@@ -910,7 +1336,7 @@ func (m *M3) silent_Internal_E() bool {
  * for the 'E' not to be pronounced
  *
  */
-func (m *M3) e_Silent_Suffix(at int) bool {
+func (m *metaph) e_Silent_Suffix(at int) bool {
 	if (m.current == (at - 1)) && (m.length > (at + 1)) && (isVowel(m.charAt(at+1)) || (m.stringAt(at, 2, "ST", "SL", "") && (m.length > (at + 2)))) {
 		return true
 	}
@@ -923,7 +1349,7 @@ func (m *M3) e_Silent_Suffix(at int) bool {
  * cause the 'e' to be pronounced
  *
  */
-func (m *M3) e_Pronouncing_Suffix(at int) bool {
+func (m *metaph) e_Pronouncing_Suffix(at int) bool {
 	// e.g. 'bridgewood' - the other vowels will get eaten
 	// up so we need to put one in here
 	if (m.length == (at + 4)) && m.stringAt(at, 4, "WOOD", "") {
@@ -963,18 +1389,9 @@ func (m *M3) e_Pronouncing_Suffix(at int) bool {
  * @return true if 'E' pronounced
  *
  */
-func (m *M3) e_Pronounced_Exceptions() bool {
+func (m *metaph) e_Pronounced_Exceptions() bool {
 	// greek names e.g. "herakles" or hispanic names e.g. "robles", where 'e' is pronounced, other exceptions
-	if (((m.current + 1) == m.last) && (m.stringAt((m.current-3), 5, "OCLES", "ACLES", "AKLES", "") || m.stringAt(0, 4, "INES", "") || m.stringAt(0, 5, "LOPES", "ESTES", "GOMES", "NUNES", "ALVES", "ICKES",
-		"INNES", "PERES", "WAGES", "NEVES", "BENES", "DONES", "") || m.stringAt(0, 6, "CORTES", "CHAVES", "VALDES", "ROBLES", "TORRES", "FLORES", "BORGES",
-		"NIEVES", "MONTES", "SOARES", "VALLES", "GEDDES", "ANDRES", "VIAJES",
-		"CALLES", "FONTES", "HERMES", "ACEVES", "BATRES", "MATHES", "") || m.stringAt(0, 7, "DELORES", "MORALES", "DOLORES", "ANGELES", "ROSALES", "MIRELES", "LINARES",
-		"PERALES", "PAREDES", "BRIONES", "SANCHES", "CAZARES", "REVELES", "ESTEVES",
-		"ALVARES", "MATTHES", "SOLARES", "CASARES", "CACERES", "STURGES", "RAMIRES",
-		"FUNCHES", "BENITES", "FUENTES", "PUENTES", "TABARES", "HENTGES", "VALORES", "") || m.stringAt(0, 8, "GONZALES", "MERCEDES", "FAGUNDES", "JOHANNES", "GONSALES", "BERMUDES",
-		"CESPEDES", "BETANCES", "TERRONES", "DIOGENES", "CORRALES", "CABRALES",
-		"MARTINES", "GRAJALES", "") || m.stringAt(0, 9, "CERVANTES", "FERNANDES", "GONCALVES", "BENEVIDES", "CIFUENTES", "SIFUENTES",
-		"SERVANTES", "HERNANDES", "BENAVIDES", "") || m.stringAt(0, 10, "ARCHIMEDES", "CARRIZALES", "MAGALLANES", ""))) || m.stringAt(m.current-2, 4, "FRED", "DGES", "DRED", "GNES", "") || m.stringAt((m.current-5), 7, "PROBLEM", "RESPLEN", "") || m.stringAt((m.current-4), 6, "REPLEN", "") || m.stringAt((m.current-3), 4, "SPLE", "") {
+	if (((m.current + 1) == m.last) && (m.stringAt((m.current-3), 5, "OCLES", "ACLES", "AKLES", "") || m.startsWith(m.exceptions.EPronouncedBeforeS))) || m.stringAt(m.current-2, 4, "FRED", "DGES", "DRED", "GNES", "") || m.stringAt((m.current-5), 7, "PROBLEM", "RESPLEN", "") || m.stringAt((m.current-4), 6, "REPLEN", "") || m.stringAt((m.current-3), 4, "SPLE", "") {
 		return true
 	}
 
@@ -986,7 +1403,7 @@ func (m *M3) e_Pronounced_Exceptions() bool {
  *
  * @return true if encoding handled in this routine, false if not
  */
-func (m *M3) skip_Silent_UE() bool {
+func (m *metaph) skip_Silent_UE() bool {
 	// always silent except for cases listed below
 	if (m.stringAt((m.current-1), 3, "QUE", "GUE", "") && !m.stringAt(0, 8, "BARBEQUE", "PALENQUE", "APPLIQUE", "") &&
 		// '-que' cases usually french but missing the acute accent
@@ -1003,7 +1420,7 @@ func (m *M3) skip_Silent_UE() bool {
  *
  *
  */
-func (m *M3) encode_B() {
+func (m *metaph) encode_B() {
 	if m.encode_Silent_B() {
 		return
 	}
@@ -1026,7 +1443,7 @@ func (m *M3) encode_B() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_B() bool {
+func (m *metaph) encode_Silent_B() bool {
 	//'debt', 'doubt', 'subtle'
 	if m.stringAt((m.current-2), 4, "DEBT", "") || m.stringAt((m.current-2), 5, "SUBTL", "") || m.stringAt((m.current-2), 6, "SUBTIL", "") || m.stringAt((m.current-3), 5, "DOUBT", "") {
 		m.metaphAdd("T", "T")
@@ -1041,7 +1458,7 @@ func (m *M3) encode_Silent_B() bool {
  * Encodes 'C'
  *
  */
-func (m *M3) encode_C() {
+func (m *metaph) encode_C() {
 
 	if m.encode_Silent_C_At_Beginning() || m.encode_CA_To_S() || m.encode_CO_To_S() || m.encode_CH() || m.encode_CCIA() || m.encode_CC() || m.encode_CK_CG_CQ() || m.encode_C_Front_Vowel() || m.encode_Silent_C() || m.encode_CZ() || m.encode_CS() {
 		return
@@ -1074,7 +1491,7 @@ func (m *M3) encode_C() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_C_At_Beginning() bool {
+func (m *metaph) encode_Silent_C_At_Beginning() bool {
 	//skip these when at start of word
 	if (m.current == 0) && m.stringAt(m.current, 2, "CT", "CN", "") {
 		m.current += 1
@@ -1091,7 +1508,7 @@ func (m *M3) encode_Silent_C_At_Beginning() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CA_To_S() bool {
+func (m *metaph) encode_CA_To_S() bool {
 	// Special case: 'caesar'.
 	// Also, where cedilla not used, as in "linguica" => LNKS
 	if ((m.current == 0) && m.stringAt(m.current, 4, "CAES", "CAEC", "CAEM", "")) || m.stringAt(0, 8, "FRANCAIS", "FRANCAIX", "LINGUICA", "") || m.stringAt(0, 6, "FACADE", "") || m.stringAt(0, 9, "GONCALVES", "PROVENCAL", "") {
@@ -1110,7 +1527,7 @@ func (m *M3) encode_CA_To_S() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CO_To_S() bool {
+func (m *metaph) encode_CO_To_S() bool {
 	// e.g. 'coelecanth' => SLKN0
 	if (m.stringAt(m.current, 4, "COEL", "") && (isVowel(m.charAt(m.current+4)) || ((m.current + 3) == m.last))) || m.stringAt(m.current, 5, "COENA", "COENO", "") || m.stringAt(0, 8, "FRANCOIS", "MELANCON", "") || m.stringAt(0, 6, "GARCON", "") {
 		m.metaphAdd("S", "S")
@@ -1127,7 +1544,7 @@ func (m *M3) encode_CO_To_S() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CH() bool {
+func (m *metaph) encode_CH() bool {
 	if m.stringAt(m.current, 2, "CH", "") {
 		if m.encode_CHAE() || m.encode_CH_To_H() || m.encode_Silent_CH() || m.encode_ARCH() ||
 			// encode_CH_To_X() should be
@@ -1160,7 +1577,7 @@ func (m *M3) encode_CH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CHAE() bool {
+func (m *metaph) encode_CHAE() bool {
 	// e.g. 'michael'
 	if (m.current > 0) && m.stringAt((m.current+2), 2, "AE", "") {
 		if m.stringAt(0, 7, "RACHAEL", "") {
@@ -1185,7 +1602,7 @@ func (m *M3) encode_CHAE() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CH_To_H() bool {
+func (m *metaph) encode_CH_To_H() bool {
 	// hebrew => 'H', e.g. 'channukah', 'chabad'
 	if ((m.current == 0) && (m.stringAt((m.current+2), 3, "AIM", "ETH", "ELM", "") || m.stringAt((m.current+2), 4, "ASID", "AZAN", "") || m.stringAt((m.current+2), 5, "UPPAH", "UTZPA", "ALLAH", "ALUTZ", "AMETZ", "") || m.stringAt((m.current+2), 6, "ESHVAN", "ADARIM", "ANUKAH", "") || m.stringAt((m.current+2), 7, "ALLLOTH", "ANNUKAH", "AROSETH", ""))) ||
 		// and an irish name with the same encoding
@@ -1204,7 +1621,7 @@ func (m *M3) encode_CH_To_H() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_CH() bool {
+func (m *metaph) encode_Silent_CH() bool {
 	// '-ch-' not pronounced
 	if m.stringAt((m.current-2), 7, "FUCHSIA", "") || m.stringAt((m.current-2), 5, "YACHT", "") || m.stringAt(0, 8, "STRACHAN", "") || m.stringAt(0, 8, "CRICHTON", "") || (m.stringAt((m.current-3), 6, "DRACHM", "")) && !m.stringAt((m.current-3), 7, "DRACHMA", "") {
 		m.current += 2
@@ -1221,7 +1638,7 @@ func (m *M3) encode_Silent_CH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CH_To_X() bool {
+func (m *metaph) encode_CH_To_X() bool {
 	// e.g. 'approach', 'beach'
 	if (m.stringAt((m.current-2), 4, "OACH", "EACH", "EECH", "OUCH", "OOCH", "MUCH", "SUCH", "") && !m.stringAt((m.current-3), 5, "JOACH", "")) ||
 		// e.g. 'dacha', 'macho'
@@ -1241,7 +1658,7 @@ func (m *M3) encode_CH_To_X() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_English_CH_To_K() bool {
+func (m *metaph) encode_English_CH_To_K() bool {
 	//'ache', 'echo', alternate spelling of 'michael'
 	if ((m.current == 1) && rootOrInflections(m.inWord, "ACHE")) || (((m.current > 3) && rootOrInflections(m.inWord[m.current-1:], "ACHE")) && (m.stringAt(0, 3, "EAR", "") || m.stringAt(0, 4, "HEAD", "BACK", "") || m.stringAt(0, 5, "HEART", "BELLY", "TOOTH", ""))) || m.stringAt((m.current-1), 4, "ECHO", "") || m.stringAt((m.current-2), 7, "MICHEAL", "") || m.stringAt((m.current-4), 7, "JERICHO", "") || m.stringAt((m.current-5), 7, "LEPRECH", "") {
 		m.metaphAdd("K", "X")
@@ -1259,7 +1676,7 @@ func (m *M3) encode_English_CH_To_K() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Germanic_CH_To_K() bool {
+func (m *metaph) encode_Germanic_CH_To_K() bool {
 	// various germanic
 	// "<consonant><vowel>CH-"implies a german word where 'ch' => K
 	if ((m.current > 1) && !isVowel(m.charAt(m.current-2)) && m.stringAt((m.current-1), 3, "ACH", "") && !m.stringAt((m.current-2), 7, "MACHADO", "MACHUCA", "LACHANC", "LACHAPE", "KACHATU", "") && !m.stringAt((m.current-3), 7, "KHACHAT", "") && ((m.charAt(m.current+2) != 'I') && ((m.charAt(m.current+2) != 'E') || m.stringAt((m.current-2), 6, "BACHER", "MACHER", "MACHEN", "LACHER", ""))) ||
@@ -1293,7 +1710,7 @@ func (m *M3) encode_Germanic_CH_To_K() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ARCH() bool {
+func (m *metaph) encode_ARCH() bool {
 	if m.stringAt((m.current - 2), 4, "ARCH", "") {
 		// "-ARCH-" has many combining forms where "-CH-" => K because of its
 		// derivation from the greek
@@ -1316,7 +1733,7 @@ func (m *M3) encode_ARCH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Greek_CH_Initial() bool {
+func (m *metaph) encode_Greek_CH_Initial() bool {
 	// greek roots e.g. 'chemistry', 'chorus', ch at beginning of root
 	if (m.stringAt(m.current, 6, "CHAMOM", "CHARAC", "CHARIS", "CHARTO", "CHARTU", "CHARYB", "CHRIST", "CHEMIC", "CHILIA", "") || (m.stringAt(m.current, 5, "CHEMI", "CHEMO", "CHEMU", "CHEMY", "CHOND", "CHONA", "CHONI", "CHOIR", "CHASM",
 		"CHARO", "CHROM", "CHROI", "CHAMA", "CHALC", "CHALD", "CHAET", "CHIRO", "CHILO", "CHELA", "CHOUS",
@@ -1359,7 +1776,7 @@ func (m *M3) encode_Greek_CH_Initial() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Greek_CH_Non_Initial() bool {
+func (m *metaph) encode_Greek_CH_Non_Initial() bool {
 	//greek & other roots e.g. 'tachometer', 'orchid', ch in middle or end of root
 	if m.stringAt((m.current-2), 6, "ORCHID", "NICHOL", "MECHAN", "LICHEN", "MACHIC", "PACHEL", "RACHIF", "RACHID",
 		"RACHIS", "RACHIC", "MICHAL", "") || m.stringAt((m.current-3), 5, "MELCH", "GLOCH", "TRACH", "TROCH", "BRACH", "SYNCH", "PSYCH",
@@ -1386,7 +1803,7 @@ func (m *M3) encode_Greek_CH_Non_Initial() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CCIA() bool {
+func (m *metaph) encode_CCIA() bool {
 	//e.g., 'focaccia'
 	if m.stringAt((m.current + 1), 3, "CIA", "") {
 		m.metaphAdd("X", "S")
@@ -1403,7 +1820,7 @@ func (m *M3) encode_CCIA() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CC() bool {
+func (m *metaph) encode_CC() bool {
 	//double 'C', but not if e.g. 'McClellan'
 	if m.stringAt(m.current, 2, "CC", "") && !((m.current == 1) && (m.charAt(0) == 'M')) {
 		// exception
@@ -1445,7 +1862,7 @@ func (m *M3) encode_CC() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CK_CG_CQ() bool {
+func (m *metaph) encode_CK_CG_CQ() bool {
 	if m.stringAt(m.current, 2, "CK", "CG", "CQ", "") {
 		// eastern european spelling e.g. 'gorecki' == 'goresky'
 		if m.stringAt(m.current, 3, "CKI", "CKY", "") && ((m.current + 2) == m.last) && (m.length > 6) {
@@ -1471,8 +1888,8 @@ func (m *M3) encode_CK_CG_CQ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_C_Front_Vowel() bool {
-	if m.stringAt(m.current, 2, "CI", "CE", "CY", "") {
+func (m *metaph) encode_C_Front_Vowel() bool {
+	if m.front_Vowel(m.current + 1) {
 		if m.encode_British_Silent_CE() || m.encode_CE() || m.encode_CI() || m.encode_Latinate_Suffixes() {
 			m.advanceCounter(2, 1)
 			return true
@@ -1491,7 +1908,7 @@ func (m *M3) encode_C_Front_Vowel() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_British_Silent_CE() bool {
+func (m *metaph) encode_British_Silent_CE() bool {
 	// english place names like e.g.'gloucester' pronounced glo-ster
 	if (m.stringAt((m.current+1), 5, "ESTER", "") && ((m.current + 5) == m.last)) || m.stringAt((m.current+1), 10, "ESTERSHIRE", "") {
 		return true
@@ -1505,7 +1922,7 @@ func (m *M3) encode_British_Silent_CE() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CE() bool {
+func (m *metaph) encode_CE() bool {
 	// 'ocean', 'commercial', 'provincial', 'cello', 'fettucini', 'medici'
 	if (m.stringAt((m.current+1), 3, "EAN", "") && isVowel(m.charAt(m.current-1))) ||
 		// e.g. 'rosacea'
@@ -1528,7 +1945,7 @@ func (m *M3) encode_CE() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CI() bool {
+func (m *metaph) encode_CI() bool {
 	// with consonant before C
 	// e.g. 'fettucini', but exception for the americanized pronunciation of 'mancini'
 	if ((m.stringAt((m.current+1), 3, "INI", "") && !m.stringAt(0, 7, "MANCINI", "")) && ((m.current + 3) == m.last)) ||
@@ -1576,7 +1993,7 @@ func (m *M3) encode_CI() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Latinate_Suffixes() bool {
+func (m *metaph) encode_Latinate_Suffixes() bool {
 	if m.stringAt((m.current + 1), 4, "EOUS", "IOUS", "") {
 		m.metaphAdd("X", "S")
 		return true
@@ -1591,7 +2008,7 @@ func (m *M3) encode_Latinate_Suffixes() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_C() bool {
+func (m *metaph) encode_Silent_C() bool {
 	if m.stringAt((m.current + 1), 1, "T", "S", "") {
 		if m.stringAt(0, 11, "CONNECTICUT", "") || m.stringAt(0, 6, "INDICT", "TUCSON", "") {
 			m.current++
@@ -1609,7 +2026,7 @@ func (m *M3) encode_Silent_C() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CZ() bool {
+func (m *metaph) encode_CZ() bool {
 	if m.stringAt((m.current+1), 1, "Z", "") && !m.stringAt((m.current-1), 6, "ECZEMA", "") {
 		if m.stringAt(m.current, 4, "CZAR", "") {
 			m.metaphAdd("S", "S")
@@ -1630,7 +2047,7 @@ func (m *M3) encode_CZ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_CS() bool {
+func (m *metaph) encode_CS() bool {
 	// give an 'etymological' 2nd
 	// encoding for "kovacs" so
 	// that it matches "kovach"
@@ -1653,7 +2070,7 @@ func (m *M3) encode_CS() bool {
  * Encode "-D-"
  *
  */
-func (m *M3) encode_D() {
+func (m *metaph) encode_D() {
 	if m.encode_DG() || m.encode_DJ() || m.encode_DT_DD() || m.encode_D_To_J() || m.encode_DOUS() || m.encode_Silent_D() {
 		return
 	}
@@ -1678,7 +2095,7 @@ func (m *M3) encode_D() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_DG() bool {
+func (m *metaph) encode_DG() bool {
 	if m.stringAt(m.current, 2, "DG", "") {
 		// excludes exceptions e.g. 'edgar',
 		// or cases where 'g' is first letter of combining form
@@ -1710,7 +2127,7 @@ func (m *M3) encode_DG() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_DJ() bool {
+func (m *metaph) encode_DJ() bool {
 	// e.g. "adjacent"
 	if m.stringAt(m.current, 2, "DJ", "") {
 		m.metaphAdd("J", "J")
@@ -1727,7 +2144,7 @@ func (m *M3) encode_DJ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_DT_DD() bool {
+func (m *metaph) encode_DT_DD() bool {
 	// eat redundant 'T' or 'D'
 	if m.stringAt(m.current, 2, "DT", "DD", "") {
 		if m.stringAt(m.current, 3, "DTH", "") {
@@ -1758,7 +2175,7 @@ func (m *M3) encode_DT_DD() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_D_To_J() bool {
+func (m *metaph) encode_D_To_J() bool {
 	// e.g. "module", "adulate"
 	if (m.stringAt(m.current, 3, "DUL", "") && (isVowel(m.charAt(m.current-1)) && isVowel(m.charAt(m.current+3)))) ||
 		// e.g. "soldier", "grandeur", "procedure"
@@ -1781,7 +2198,7 @@ func (m *M3) encode_D_To_J() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_DOUS() bool {
+func (m *metaph) encode_DOUS() bool {
 	// e.g. "assiduous", "arduous"
 	if m.stringAt((m.current + 1), 4, "UOUS", "") {
 		m.metaphAddExactApprox4("J", "D", "J", "T")
@@ -1798,7 +2215,7 @@ func (m *M3) encode_DOUS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_D() bool {
+func (m *metaph) encode_Silent_D() bool {
 	// silent 'D' e.g. 'wednesday', 'handsome'
 	if m.stringAt((m.current-2), 9, "WEDNESDAY", "") || m.stringAt((m.current-3), 7, "HANDKER", "HANDSOM", "WINDSOR", "") ||
 		// french silent D at end in words or names familiar to americans
@@ -1814,7 +2231,7 @@ func (m *M3) encode_Silent_D() bool {
  * Encode "-F-"
  *
  */
-func (m *M3) encode_F() {
+func (m *metaph) encode_F() {
 	// Encode cases where "-FT-" => "T" is usually silent
 	// e.g. 'often', 'soften'
 	// This should really be covered under "T"!
@@ -1839,7 +2256,7 @@ func (m *M3) encode_F() {
  * Encode "-G-"
  *
  */
-func (m *M3) encode_G() {
+func (m *metaph) encode_G() {
 	if m.encode_Silent_G_At_Beginning() || m.encode_GG() || m.encode_GK() || m.encode_GH() || m.encode_Silent_G() || m.encode_GN() || m.encode_GL() || m.encode_Initial_G_Front_Vowel() || m.encode_NGER() || m.encode_GER() || m.encode_GEL() || m.encode_Non_Initial_G_Front_Vowel() || m.encode_GA_To_J() {
 		return
 	}
@@ -1857,7 +2274,7 @@ func (m *M3) encode_G() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_G_At_Beginning() bool {
+func (m *metaph) encode_Silent_G_At_Beginning() bool {
 	//skip these when at start of word
 	if (m.current == 0) && m.stringAt(m.current, 2, "GN", "") {
 		m.current += 1
@@ -1873,7 +2290,7 @@ func (m *M3) encode_Silent_G_At_Beginning() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GG() bool {
+func (m *metaph) encode_GG() bool {
 	if m.charAt(m.current+1) == 'G' {
 		// italian e.g, 'loggia', 'caraveggio', also 'suggest' and 'exaggerate'
 		if m.stringAt((m.current-1), 5, "AGGIA", "OGGIA", "AGGIO", "EGGIO", "EGGIA", "IGGIO", "") ||
@@ -1902,7 +2319,7 @@ func (m *M3) encode_GG() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GK() bool {
+func (m *metaph) encode_GK() bool {
 	// 'gingko'
 	if m.charAt(m.current+1) == 'K' {
 		m.metaphAdd("K", "K")
@@ -1919,7 +2336,7 @@ func (m *M3) encode_GK() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH() bool {
+func (m *metaph) encode_GH() bool {
 	if m.charAt(m.current+1) == 'H' {
 		if m.encode_GH_After_Consonant() || m.encode_Initial_GH() || m.encode_GH_To_J() || m.encode_GH_To_H() || m.encode_UGHT() || m.encode_GH_H_Part_Of_Other_Word() || m.encode_Silent_GH() || m.encode_GH_To_F() {
 			return true
@@ -1938,7 +2355,7 @@ func (m *M3) encode_GH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_After_Consonant() bool {
+func (m *metaph) encode_GH_After_Consonant() bool {
 	// e.g. 'burgher', 'bingham'
 	if (m.current > 0) && !isVowel(m.charAt(m.current-1)) &&
 		// not e.g. 'greenhalgh'
@@ -1956,7 +2373,7 @@ func (m *M3) encode_GH_After_Consonant() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_GH() bool {
+func (m *metaph) encode_Initial_GH() bool {
 	if m.current < 3 {
 		// e.g. "ghislane", "ghiradelli"
 		if m.current == 0 {
@@ -1978,7 +2395,7 @@ func (m *M3) encode_Initial_GH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_To_J() bool {
+func (m *metaph) encode_GH_To_J() bool {
 	// e.g., 'greenhalgh', 'dunkenhalgh', english names
 	if m.stringAt((m.current-2), 4, "ALGH", "") && ((m.current + 1) == m.last) {
 		m.metaphAdd("J", "")
@@ -1994,7 +2411,7 @@ func (m *M3) encode_GH_To_J() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_To_H() bool {
+func (m *metaph) encode_GH_To_H() bool {
 	// special cases
 	// e.g., 'donoghue', 'donaghy'
 	if (m.stringAt((m.current-4), 4, "DONO", "DONA", "") && isVowel(m.charAt(m.current+2))) || m.stringAt((m.current-5), 9, "CALLAGHAN", "") {
@@ -2011,7 +2428,7 @@ func (m *M3) encode_GH_To_H() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_UGHT() bool {
+func (m *metaph) encode_UGHT() bool {
 	//e.g. "ought", "aught", "daughter", "slaughter"
 	if m.stringAt((m.current - 1), 4, "UGHT", "") {
 		if (m.stringAt((m.current-3), 5, "LAUGH", "") && !(m.stringAt((m.current-4), 7, "SLAUGHT", "") || m.stringAt((m.current-3), 7, "LAUGHTO", ""))) || m.stringAt((m.current-4), 6, "DRAUGH", "") {
@@ -2031,7 +2448,7 @@ func (m *M3) encode_UGHT() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_H_Part_Of_Other_Word() bool {
+func (m *metaph) encode_GH_H_Part_Of_Other_Word() bool {
 	// if the 'H' is the beginning of another word or syllable
 	if m.stringAt((m.current + 1), 4, "HOUS", "HEAD", "HOLE", "HORN", "HARN", "") {
 		m.metaphAddExactApprox("G", "K")
@@ -2047,7 +2464,7 @@ func (m *M3) encode_GH_H_Part_Of_Other_Word() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_GH() bool {
+func (m *metaph) encode_Silent_GH() bool {
 	//Parker's rule (with some further refinements) - e.g., 'hugh'
 	if ((((m.current > 1) && m.stringAt((m.current-2), 1, "B", "H", "D", "G", "L", "")) ||
 		//e.g., 'bough'
@@ -2081,7 +2498,7 @@ func (m *M3) encode_Silent_GH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_Special_Cases() bool {
+func (m *metaph) encode_GH_Special_Cases() bool {
 	handled := false
 
 	// special case: 'hiccough' == 'hiccup'
@@ -2121,7 +2538,7 @@ func (m *M3) encode_GH_Special_Cases() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GH_To_F() bool {
+func (m *metaph) encode_GH_To_F() bool {
 	// the cases covered here would fall under
 	// the GH_To_F rule below otherwise
 	if m.encode_GH_Special_Cases() {
@@ -2144,7 +2561,7 @@ func (m *M3) encode_GH_To_F() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_G() bool {
+func (m *metaph) encode_Silent_G() bool {
 	// e.g. "phlegm", "apothegm", "voigt"
 	if (((m.current + 1) == m.last) && (m.stringAt((m.current-1), 3, "EGM", "IGM", "AGM", "") || m.stringAt(m.current, 2, "GT", ""))) || (m.stringAt(0, 5, "HUGES", "") && (m.length == 5)) {
 		m.current++
@@ -2166,7 +2583,7 @@ func (m *M3) encode_Silent_G() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GN() bool {
+func (m *metaph) encode_GN() bool {
 	if m.charAt(m.current+1) == 'N' {
 		// 'align' 'sign', 'resign' but not 'resignation'
 		// also 'impugn', 'impugnable', but not 'repugnant'
@@ -2195,7 +2612,7 @@ func (m *M3) encode_GN() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GL() bool {
+func (m *metaph) encode_GL() bool {
 	//'tagliaro', 'puglia' BUT add K in alternative
 	// since americans sometimes do this
 	if m.stringAt((m.current+1), 3, "LIA", "LIO", "LIE", "") && isVowel(m.charAt(m.current-1)) {
@@ -2212,7 +2629,7 @@ func (m *M3) encode_GL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) initial_G_Soft() bool {
+func (m *metaph) initial_G_Soft() bool {
 	if ((m.stringAt((m.current+1), 2, "EL", "EM", "EN", "EO", "ER", "ES", "IA", "IN", "IO", "IP", "IU", "YM", "YN", "YP", "YR", "EE", "") || m.stringAt((m.current+1), 3, "IRA", "IRO", "")) &&
 		// except for smaller set of cases where => K, e.g. "gerber"
 		!(m.stringAt((m.current+1), 3, "ELD", "ELT", "ERT", "INZ", "ERH", "ITE", "ERD", "ERL", "ERN",
@@ -2231,7 +2648,7 @@ func (m *M3) initial_G_Soft() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_G_Front_Vowel() bool {
+func (m *metaph) encode_Initial_G_Front_Vowel() bool {
 	// 'g' followed by vowel at beginning
 	if (m.current == 0) && m.front_Vowel(m.current+1) {
 		// special case "gila" as in "gila monster"
@@ -2261,7 +2678,7 @@ func (m *M3) encode_Initial_G_Front_Vowel() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_NGER() bool {
+func (m *metaph) encode_NGER() bool {
 	if (m.current > 1) && m.stringAt((m.current-1), 4, "NGER", "") {
 		// default 'G' => J  such as 'ranger', 'stranger', 'manger', 'messenger', 'orangery', 'granger'
 		// 'boulanger', 'challenger', 'danger', 'changer', 'harbinger', 'lounger', 'ginger', 'passenger'
@@ -2289,7 +2706,7 @@ func (m *M3) encode_NGER() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GER() bool {
+func (m *metaph) encode_GER() bool {
 	if (m.current > 0) && m.stringAt((m.current+1), 2, "ER", "") {
 		// Exceptions to 'GE' where 'G' => K
 		// e.g. "JAGER", "TIGER", "LIGER", "LAGER", "LUGER", "AUGER", "EAGER", "HAGER", "SAGER"
@@ -2326,7 +2743,7 @@ func (m *M3) encode_GER() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GEL() bool {
+func (m *metaph) encode_GEL() bool {
 	// more likely to be "-GEL-" => JL
 	if m.stringAt((m.current+1), 2, "EL", "") && (m.current > 0) {
 		// except for
@@ -2358,9 +2775,9 @@ func (m *M3) encode_GEL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Non_Initial_G_Front_Vowel() bool {
+func (m *metaph) encode_Non_Initial_G_Front_Vowel() bool {
 	// -gy-, gi-, ge-
-	if m.stringAt((m.current + 1), 1, "E", "I", "Y", "") {
+	if m.front_Vowel(m.current + 1) {
 		// '-ge' at end
 		// almost always 'j 'sound
 		if m.stringAt(m.current, 2, "GE", "") && (m.current == (m.last - 1)) {
@@ -2401,7 +2818,7 @@ func (m *M3) encode_Non_Initial_G_Front_Vowel() bool {
  *
  * @return true if encoding handled in this routine, false if not
  */
-func (m *M3) hard_GE_At_End() bool {
+func (m *metaph) hard_GE_At_End() bool {
 	if m.stringAt(0, 6, "RENEGE", "STONGE", "STANGE", "PRANGE", "KRESGE", "") || m.stringAt(0, 5, "BYRGE", "BIRGE", "BERGE", "HAUGE", "") || m.stringAt(0, 4, "HAGE", "") || m.stringAt(0, 5, "LANGE", "SYNGE", "BENGE", "RUNGE", "HELGE", "") || m.stringAt(0, 4, "INGE", "LAGE", "") {
 		return true
 	}
@@ -2417,7 +2834,7 @@ func (m *M3) hard_GE_At_End() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) internal_Hard_G() bool {
+func (m *metaph) internal_Hard_G() bool {
 	// if not "-GE" at end
 	if !(((m.current + 1) == m.last) && (m.charAt(m.current+1) == 'E')) && (m.internal_Hard_NG() || m.internal_Hard_GEN_GIN_GET_GIT() || m.internal_Hard_G_Open_Syllable() || m.internal_Hard_G_Other()) {
 		return true
@@ -2433,7 +2850,7 @@ func (m *M3) internal_Hard_G() bool {
  * @return true if 'hard' 'g' detected
  *
  */
-func (m *M3) internal_Hard_G_Other() bool {
+func (m *metaph) internal_Hard_G_Other() bool {
 	if (m.stringAt(m.current, 4, "GETH", "GEAR", "GEIS", "GIRL", "GIVI", "GIVE", "GIFT",
 		"GIRD", "GIRT", "GILV", "GILD", "GELD", "") && !m.stringAt((m.current-3), 6, "GINGIV", "")) ||
 		// "gish" but not "largish"
@@ -2452,7 +2869,7 @@ func (m *M3) internal_Hard_G_Other() bool {
  * @return true if 'hard' 'g' detected
  *
  */
-func (m *M3) internal_Hard_G_Open_Syllable() bool {
+func (m *metaph) internal_Hard_G_Open_Syllable() bool {
 	if m.stringAt((m.current+1), 3, "EYE", "") || m.stringAt((m.current-2), 4, "FOGY", "POGY", "YOGI", "") || m.stringAt((m.current-2), 5, "MAGEE", "MCGEE", "HAGIO", "") || m.stringAt((m.current-1), 4, "RGEY", "OGEY", "") || m.stringAt((m.current-3), 5, "HOAGY", "STOGY", "PORGY", "") || m.stringAt((m.current-5), 8, "CARNEGIE", "") || (m.stringAt((m.current-1), 4, "OGEY", "OGIE", "") && ((m.current + 2) == m.last)) {
 		return true
 	}
@@ -2467,7 +2884,7 @@ func (m *M3) internal_Hard_G_Open_Syllable() bool {
  * @return true if 'hard' 'g' detected, false if not
  *
  */
-func (m *M3) internal_Hard_GEN_GIN_GET_GIT() bool {
+func (m *metaph) internal_Hard_GEN_GIN_GET_GIT() bool {
 	if (m.stringAt((m.current-3), 6, "FORGET", "TARGET", "MARGIT", "MARGET", "TURGEN",
 		"BERGEN", "MORGEN", "JORGEN", "HAUGEN", "JERGEN",
 		"JURGEN", "LINGEN", "BORGEN", "LANGEN", "KLAGEN", "STIGER", "BERGER", "") && !m.stringAt(m.current, 7, "GENETIC", "GENESIS", "") && !m.stringAt((m.current-4), 8, "PLANGENT", "")) || (m.stringAt((m.current-3), 6, "BERGIN", "FEAGIN", "DURGIN", "") && ((m.current + 2) == m.last)) || (m.stringAt((m.current-2), 5, "ENGEN", "") && !m.stringAt((m.current+3), 3, "DER", "ETI", "ESI", "")) || m.stringAt((m.current-4), 7, "JUERGEN", "") || m.stringAt(0, 5, "NAGIN", "MAGIN", "HAGIN", "") || (m.stringAt(0, 5, "ENGIN", "DEGEN", "LAGEN", "MAGEN", "NAGIN", "") && (m.length == 5)) || (m.stringAt((m.current-2), 5, "BEGET", "BEGIN", "HAGEN", "FAGIN",
@@ -2487,7 +2904,7 @@ func (m *M3) internal_Hard_GEN_GIN_GET_GIT() bool {
  * @return true if 'hard' 'g' detected, false if not
  *
  */
-func (m *M3) internal_Hard_NG() bool {
+func (m *metaph) internal_Hard_NG() bool {
 	if (m.stringAt((m.current-3), 4, "DANG", "FANG", "SING", "") &&
 		// exception to exception
 		!m.stringAt((m.current-5), 8, "DISINGEN", "")) || m.stringAt(0, 5, "INGEB", "ENGEB", "") || (m.stringAt((m.current-3), 4, "RING", "WING", "HANG", "LONG", "") && !(m.stringAt((m.current-4), 5, "CRING", "FRING", "ORANG", "TWING", "CHANG", "PHANG", "") || m.stringAt((m.current-5), 6, "SYRING", "") || m.stringAt((m.current-3), 7, "RINGENC", "RINGENT", "LONGITU", "LONGEVI", "") ||
@@ -2507,7 +2924,7 @@ func (m *M3) internal_Hard_NG() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_GA_To_J() bool {
+func (m *metaph) encode_GA_To_J() bool {
 	// 'margary', 'margarine'
 	if (m.stringAt((m.current-3), 7, "MARGARY", "MARGARI", "") &&
 		// but not in spanish forms such as "margatita"
@@ -2526,7 +2943,7 @@ func (m *M3) encode_GA_To_J() bool {
  *
  *
  */
-func (m *M3) encode_H() {
+func (m *metaph) encode_H() {
 	if m.encode_Initial_Silent_H() || m.encode_Initial_HS() || m.encode_Initial_HU_HW() || m.encode_Non_Initial_Silent_H() {
 		return
 	}
@@ -2544,7 +2961,7 @@ func (m *M3) encode_H() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_Silent_H() bool {
+func (m *metaph) encode_Initial_Silent_H() bool {
 	//'hour', 'herb', 'heir', 'honor'
 	if m.stringAt((m.current+1), 3, "OUR", "ERB", "EIR", "") || m.stringAt((m.current+1), 4, "ONOR", "") || m.stringAt((m.current+1), 5, "ONOUR", "ONEST", "") {
 		// british pronounce H in this word
@@ -2575,7 +2992,7 @@ func (m *M3) encode_Initial_Silent_H() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_HS() bool {
+func (m *metaph) encode_Initial_HS() bool {
 	// old chinese pinyin transliteration
 	// e.g., 'HSIAO'
 	if (m.current == 0) && m.stringAt(0, 2, "HS", "") {
@@ -2593,7 +3010,7 @@ func (m *M3) encode_Initial_HS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_HU_HW() bool {
+func (m *metaph) encode_Initial_HU_HW() bool {
 	// spanish spellings and chinese pinyin transliteration
 	if m.stringAt(0, 3, "HUA", "HUE", "HWA", "") {
 		if !m.stringAt(m.current, 4, "HUEY", "") {
@@ -2621,7 +3038,7 @@ func (m *M3) encode_Initial_HU_HW() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Non_Initial_Silent_H() bool {
+func (m *metaph) encode_Non_Initial_Silent_H() bool {
 	//exceptions - 'h' not pronounced
 	// "PROHIB" BUT NOT "PROHIBIT"
 	if m.stringAt((m.current-2), 5, "NIHIL", "VEHEM", "LOHEN", "NEHEM",
@@ -2646,7 +3063,7 @@ func (m *M3) encode_Non_Initial_Silent_H() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_H_Pronounced() bool {
+func (m *metaph) encode_H_Pronounced() bool {
 	if (((m.current == 0) || isVowel(m.charAt(m.current-1)) || ((m.current > 0) && (m.charAt(m.current-1) == 'W'))) && isVowel(m.charAt(m.current+1))) ||
 		// e.g. 'alWahhab'
 		((m.charAt(m.current+1) == 'H') && isVowel(m.charAt(m.current+2))) {
@@ -2662,7 +3079,7 @@ func (m *M3) encode_H_Pronounced() bool {
  * Encode 'J'
  *
  */
-func (m *M3) encode_J() {
+func (m *metaph) encode_J() {
 	if m.encode_Spanish_J() || m.encode_Spanish_OJ_UJ() {
 		return
 	}
@@ -2676,7 +3093,7 @@ func (m *M3) encode_J() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Spanish_J() bool {
+func (m *metaph) encode_Spanish_J() bool {
 	//obvious spanish, e.g. "jose", "san jacinto"
 	if (m.stringAt((m.current+1), 3, "UAN", "ACI", "ALI", "EFE", "ICA", "IME", "OAQ", "UAR", "") && !m.stringAt(m.current, 8, "JIMERSON", "JIMERSEN", "")) || (m.stringAt((m.current+1), 3, "OSE", "") && ((m.current + 3) == m.last)) || m.stringAt((m.current+1), 4, "EREZ", "UNTA", "AIME", "AVIE", "AVIA", "") || m.stringAt((m.current+1), 6, "IMINEZ", "ARAMIL", "") || (((m.current + 2) == m.last) && m.stringAt((m.current-2), 5, "MEJIA", "")) || m.stringAt((m.current-2), 5, "TEJED", "TEJAD", "LUJAN", "FAJAR", "BEJAR", "BOJOR", "CAJIG",
 		"DEJAS", "DUJAR", "DUJAN", "MIJAR", "MEJOR", "NAJAR",
@@ -2726,7 +3143,7 @@ func (m *M3) encode_Spanish_J() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_German_J() bool {
+func (m *metaph) encode_German_J() bool {
 	if m.stringAt((m.current+1), 2, "AH", "") || (m.stringAt((m.current+1), 5, "OHANN", "") && ((m.current + 5) == m.last)) || (m.stringAt((m.current+1), 3, "UNG", "") && !m.stringAt((m.current+1), 4, "UNGL", "")) || m.stringAt((m.current+1), 3, "UGO", "") {
 		m.metaphAdd("A", "A")
 		m.advanceCounter(2, 1)
@@ -2742,7 +3159,7 @@ func (m *M3) encode_German_J() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Spanish_OJ_UJ() bool {
+func (m *metaph) encode_Spanish_OJ_UJ() bool {
 	if m.stringAt((m.current + 1), 5, "OJOBA", "UJUY ", "") {
 		if m.encodeVowels {
 			m.metaphAdd("HAH", "HAH")
@@ -2763,7 +3180,7 @@ func (m *M3) encode_Spanish_OJ_UJ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_J_To_J() bool {
+func (m *metaph) encode_J_To_J() bool {
 	if isVowel(m.charAt(m.current + 1)) {
 		if (m.current == 0) && m.names_Beginning_With_J_That_Get_Alt_Y() {
 			// 'Y' is a vowel so encode
@@ -2799,7 +3216,7 @@ func (m *M3) encode_J_To_J() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Spanish_J_2() bool {
+func (m *metaph) encode_Spanish_J_2() bool {
 	// spanish forms e.g. "brujo", "badajoz"
 	if (((m.current - 2) == 0) && m.stringAt((m.current-2), 4, "BOJA", "BAJA", "BEJA", "BOJO", "MOJA", "MOJI", "MEJI", "")) || (((m.current - 3) == 0) && m.stringAt((m.current-3), 5, "FRIJO", "BRUJO", "BRUJA", "GRAJE", "GRIJA", "LEIJA", "QUIJA", "")) || (((m.current + 3) == m.last) && m.stringAt((m.current-1), 5, "AJARA", "")) || (((m.current + 2) == m.last) && m.stringAt((m.current-1), 4, "AJOS", "EJOS", "OJAS", "OJOS", "UJON", "AJOZ", "AJAL", "UJAR", "EJON", "EJAN", "")) || (((m.current + 1) == m.last) && (m.stringAt((m.current-1), 3, "OJA", "EJA", "") && !m.stringAt(0, 4, "DEJA", ""))) {
 		m.metaphAdd("H", "H")
@@ -2816,7 +3233,7 @@ func (m *M3) encode_Spanish_J_2() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_J_As_Vowel() bool {
+func (m *metaph) encode_J_As_Vowel() bool {
 	if m.stringAt(m.current, 5, "JEWSK", "") {
 		m.metaphAdd("J", "")
 		return true
@@ -2839,7 +3256,7 @@ func (m *M3) encode_J_As_Vowel() bool {
  * Call routines to encode 'J', in proper order
  *
  */
-func (m *M3) encode_Other_J() {
+func (m *metaph) encode_Other_J() {
 	if m.current == 0 {
 		if m.encode_German_J() {
 			return
@@ -2870,7 +3287,7 @@ func (m *M3) encode_Other_J() {
  *
  *
  */
-func (m *M3) encode_K() {
+func (m *metaph) encode_K() {
 	if !m.encode_Silent_K() {
 		m.metaphAdd("K", "K")
 
@@ -2889,7 +3306,7 @@ func (m *M3) encode_K() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_K() bool {
+func (m *metaph) encode_Silent_K() bool {
 	//skip this except for special cases
 	if (m.current == 0) && m.stringAt(m.current, 2, "KN", "") {
 		if !(m.stringAt((m.current+2), 5, "ESSET", "IEVEL", "") || m.stringAt((m.current+2), 3, "ISH", "")) {
@@ -2923,7 +3340,7 @@ func (m *M3) encode_Silent_K() bool {
  * encoding, where 'LE' => AL
  *
  */
-func (m *M3) encode_L() {
+func (m *metaph) encode_L() {
 	// logic below needs to know this
 	// after 'm.current' variable changed
 	save_current := m.current
@@ -2946,7 +3363,7 @@ func (m *M3) encode_L() {
  * end have a schwa pronounced before the L
  *
  */
-func (m *M3) interpolate_Vowel_When_Cons_L_At_End() {
+func (m *metaph) interpolate_Vowel_When_Cons_L_At_End() {
 	if m.encodeVowels == true {
 		// e.g. "ertl", "vogl"
 		if (m.current == m.last) && m.stringAt((m.current-1), 1, "D", "G", "T", "") {
@@ -2962,7 +3379,7 @@ func (m *M3) interpolate_Vowel_When_Cons_L_At_End() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_LELY_To_L() bool {
+func (m *metaph) encode_LELY_To_L() bool {
 	// e.g. "agilely", "docilely"
 	if m.stringAt((m.current-1), 5, "ILELY", "") && ((m.current + 3) == m.last) {
 		m.metaphAdd("L", "L")
@@ -2980,7 +3397,7 @@ func (m *M3) encode_LELY_To_L() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_COLONEL() bool {
+func (m *metaph) encode_COLONEL() bool {
 	if m.stringAt((m.current - 2), 7, "COLONEL", "") {
 		m.metaphAdd("R", "R")
 		m.current += 2
@@ -2996,7 +3413,7 @@ func (m *M3) encode_COLONEL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_French_AULT() bool {
+func (m *metaph) encode_French_AULT() bool {
 	// e.g. "renault" and "foucault", well known to americans, but not "fault"
 	if (m.current > 3) && (m.stringAt((m.current-3), 5, "RAULT", "NAULT", "BAULT", "SAULT", "GAULT", "CAULT", "") || m.stringAt((m.current-4), 6, "REAULT", "RIAULT", "NEAULT", "BEAULT", "")) && !(rootOrInflections(m.inWord, "ASSAULT") || m.stringAt((m.current-8), 10, "SOMERSAULT", "") || m.stringAt((m.current-9), 11, "SUMMERSAULT", "")) {
 		m.current += 2
@@ -3012,7 +3429,7 @@ func (m *M3) encode_French_AULT() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_French_EUIL() bool {
+func (m *metaph) encode_French_EUIL() bool {
 	// e.g. "auteuil"
 	if m.stringAt((m.current-3), 4, "EUIL", "") && (m.current == m.last) {
 		m.current++
@@ -3028,7 +3445,7 @@ func (m *M3) encode_French_EUIL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_French_OULX() bool {
+func (m *metaph) encode_French_OULX() bool {
 	// e.g. "proulx"
 	if m.stringAt((m.current-2), 4, "OULX", "") && ((m.current + 1) == m.last) {
 		m.current += 2
@@ -3044,7 +3461,7 @@ func (m *M3) encode_French_OULX() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_L_In_LM() bool {
+func (m *metaph) encode_Silent_L_In_LM() bool {
 	if m.stringAt(m.current, 2, "LM", "LN", "") {
 		// e.g. "lincoln", "holmes", "psalm", "salmon"
 		if (m.stringAt((m.current-2), 4, "COLN", "CALM", "BALM", "MALM", "PALM", "") || (m.stringAt((m.current-1), 3, "OLM", "") && ((m.current + 1) == m.last)) || m.stringAt((m.current-3), 5, "PSALM", "QUALM", "") || m.stringAt((m.current-2), 6, "SALMON", "HOLMES", "") || m.stringAt((m.current-1), 6, "ALMOND", "") || ((m.current == 1) && m.stringAt((m.current-1), 4, "ALMS", ""))) && (!m.stringAt((m.current+2), 1, "A", "") && !m.stringAt((m.current-2), 5, "BALMO", "") && !m.stringAt((m.current-2), 6, "PALMER", "PALMOR", "BALMER", "") && !m.stringAt((m.current-3), 5, "THALM", "")) {
@@ -3066,7 +3483,7 @@ func (m *M3) encode_Silent_L_In_LM() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_L_In_LK_LV() bool {
+func (m *metaph) encode_Silent_L_In_LK_LV() bool {
 	if (m.stringAt((m.current-2), 4, "WALK", "YOLK", "FOLK", "HALF", "TALK", "CALF", "BALK", "CALK", "") || (m.stringAt((m.current-2), 4, "POLK", "") && !m.stringAt((m.current-2), 5, "POLKA", "WALKO", "")) || (m.stringAt((m.current-2), 4, "HALV", "") && !m.stringAt((m.current-2), 5, "HALVA", "HALVO", "")) || (m.stringAt((m.current-3), 5, "CAULK", "CHALK", "BAULK", "FAULK", "") && !m.stringAt((m.current-4), 6, "SCHALK", "")) || (m.stringAt((m.current-2), 5, "SALVE", "CALVE", "") || m.stringAt((m.current-2), 6, "SOLDER", "")) &&
 		// exceptions to above cases where 'L' is usually pronounced
 		!m.stringAt((m.current-2), 6, "SALVER", "CALVER", "")) && !m.stringAt((m.current-5), 9, "GONSALVES", "GONCALVES", "") && !m.stringAt((m.current-2), 6, "BALKAN", "TALKAL", "") && !m.stringAt((m.current-3), 5, "PAULK", "CHALF", "") {
@@ -3083,7 +3500,7 @@ func (m *M3) encode_Silent_L_In_LK_LV() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_L_In_OULD() bool {
+func (m *metaph) encode_Silent_L_In_OULD() bool {
 	//'would', 'could'
 	if m.stringAt((m.current-3), 5, "WOULD", "COULD", "") || (m.stringAt((m.current-4), 6, "SHOULD", "") && !m.stringAt((m.current-4), 8, "SHOULDER", "")) {
 		m.metaphAddExactApprox("D", "T")
@@ -3101,7 +3518,7 @@ func (m *M3) encode_Silent_L_In_OULD() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_LL_As_Vowel_Special_Cases() bool {
+func (m *metaph) encode_LL_As_Vowel_Special_Cases() bool {
 	if m.stringAt((m.current-5), 8, "TORTILLA", "") || m.stringAt((m.current-8), 11, "RATATOUILLE", "") ||
 		// e.g. 'guillermo', "veillard"
 		(m.stringAt(0, 5, "GUILL", "VEILL", "GAILL", "") &&
@@ -3126,7 +3543,7 @@ func (m *M3) encode_LL_As_Vowel_Special_Cases() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_LL_As_Vowel() bool {
+func (m *metaph) encode_LL_As_Vowel() bool {
 	//spanish e.g. "cabrillo", "gallegos" but also "gorilla", "ballerina" -
 	// give both pronounciations since an american might pronounce "cabrillo"
 	// in the spanish or the american fashion.
@@ -3144,7 +3561,7 @@ func (m *M3) encode_LL_As_Vowel() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_LL_As_Vowel_Cases() bool {
+func (m *metaph) encode_LL_As_Vowel_Cases() bool {
 	if m.charAt(m.current+1) == 'L' {
 		if m.encode_LL_As_Vowel_Special_Cases() {
 			return true
@@ -3166,7 +3583,7 @@ func (m *M3) encode_LL_As_Vowel_Cases() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Vowel_LE_Transposition(save_current int) bool {
+func (m *metaph) encode_Vowel_LE_Transposition(save_current int) bool {
 	// transposition of vowel sound and L occurs in many words,
 	// e.g. "bristle", "dazzle", "goggle" => KAKAL
 	if m.encodeVowels && (save_current > 1) && !isVowel(m.charAt(save_current-1)) && (m.charAt(save_current+1) == 'E') && (m.charAt(save_current-1) != 'L') && (m.charAt(save_current-1) != 'R') &&
@@ -3195,7 +3612,7 @@ func (m *M3) encode_Vowel_LE_Transposition(save_current int) bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Vowel_Preserve_Vowel_After_L(save_current int) bool {
+func (m *metaph) encode_Vowel_Preserve_Vowel_After_L(save_current int) bool {
 	// an example of where the vowel would NOT need to be preserved
 	// would be, say, "hustled", where there is no vowel pronounced
 	// between the 'l' and the 'd'
@@ -3214,7 +3631,7 @@ func (m *M3) encode_Vowel_Preserve_Vowel_After_L(save_current int) bool {
  * @param save_current index of actual current letter
  *
  */
-func (m *M3) encode_LE_Cases(save_current int) {
+func (m *metaph) encode_LE_Cases(save_current int) {
 	if m.encode_Vowel_LE_Transposition(save_current) {
 		return
 	} else {
@@ -3230,7 +3647,7 @@ func (m *M3) encode_LE_Cases(save_current int) {
  * Encode "-M-"
  *
  */
-func (m *M3) encode_M() {
+func (m *metaph) encode_M() {
 	if m.encode_Silent_M_At_Beginning() || m.encode_MR_And_MRS() || m.encode_MAC() || m.encode_MPT() {
 		return
 	}
@@ -3248,7 +3665,7 @@ func (m *M3) encode_M() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_M_At_Beginning() bool {
+func (m *metaph) encode_Silent_M_At_Beginning() bool {
 	//skip these when at start of word
 	if (m.current == 0) && m.stringAt(m.current, 2, "MN", "") {
 		m.current += 1
@@ -3264,7 +3681,7 @@ func (m *M3) encode_Silent_M_At_Beginning() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_MR_And_MRS() bool {
+func (m *metaph) encode_MR_And_MRS() bool {
 	if (m.current == 0) && m.stringAt(m.current, 2, "MR", "") {
 		// exceptions for "mr." and "mrs."
 		if (m.length == 2) && m.stringAt(m.current, 2, "MR", "") {
@@ -3295,7 +3712,7 @@ func (m *M3) encode_MR_And_MRS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_MAC() bool {
+func (m *metaph) encode_MAC() bool {
 	// should only find irish and
 	// scottish names e.g. 'macintosh'
 	if (m.current == 0) && (m.stringAt(0, 7, "MACIVER", "MACEWEN", "") || m.stringAt(0, 8, "MACELROY", "MACILROY", "") || m.stringAt(0, 9, "MACINTOSH", "") || m.stringAt(0, 2, "MC", "")) {
@@ -3329,7 +3746,7 @@ func (m *M3) encode_MAC() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_MPT() bool {
+func (m *metaph) encode_MPT() bool {
 	if m.stringAt((m.current-2), 8, "COMPTROL", "") || m.stringAt((m.current-4), 7, "ACCOMPT", "") {
 		m.metaphAdd("N", "N")
 		m.current += 2
@@ -3345,7 +3762,7 @@ func (m *M3) encode_MPT() bool {
  * @return true if 'B' is silent in this context
  *
  */
-func (m *M3) test_Silent_MB_1() bool {
+func (m *metaph) test_Silent_MB_1() bool {
 	// e.g. "LAMB", "COMB", "LIMB", "DUMB", "BOMB"
 	// Handle combining roots first
 	if ((m.current == 3) && m.stringAt((m.current-3), 5, "THUMB", "")) || ((m.current == 2) && m.stringAt((m.current-2), 4, "DUMB", "BOMB", "DAMN", "LAMB", "NUMB", "TOMB", "")) {
@@ -3361,7 +3778,7 @@ func (m *M3) test_Silent_MB_1() bool {
  * @return true if 'B' is pronounced in this context
  *
  */
-func (m *M3) test_Pronounced_MB() bool {
+func (m *metaph) test_Pronounced_MB() bool {
 	if m.stringAt((m.current-2), 6, "NUMBER", "") || (m.stringAt((m.current+2), 1, "A", "") && !m.stringAt((m.current-2), 7, "DUMBASS", "")) || m.stringAt((m.current+2), 1, "O", "") || m.stringAt((m.current-2), 6, "LAMBEN", "LAMBER", "LAMBET", "TOMBIG", "LAMBRE", "") {
 		return true
 	}
@@ -3375,7 +3792,7 @@ func (m *M3) test_Pronounced_MB() bool {
  * @return true if 'B' is silent in this context
  *
  */
-func (m *M3) test_Silent_MB_2() bool {
+func (m *metaph) test_Silent_MB_2() bool {
 	// 'M' is the current letter
 	if (m.charAt(m.current+1) == 'B') && (m.current > 1) && (((m.current + 1) == m.last) ||
 		// other situations where "-MB-" is at end of root
@@ -3401,7 +3818,7 @@ func (m *M3) test_Silent_MB_2() bool {
  * @return true if "-B-" is pronounced in these contexts
  *
  */
-func (m *M3) test_Pronounced_MB_2() bool {
+func (m *metaph) test_Pronounced_MB_2() bool {
 	// e.g. "bombastic", "umbrage", "flamboyant"
 	if m.stringAt((m.current-1), 5, "OMBAS", "OMBAD", "UMBRA", "") || m.stringAt((m.current-3), 4, "FLAM", "") {
 		return true
@@ -3416,7 +3833,7 @@ func (m *M3) test_Pronounced_MB_2() bool {
  * @return true if "-N-" is silent in these contexts
  *
  */
-func (m *M3) test_MN() bool {
+func (m *metaph) test_MN() bool {
 
 	if (m.charAt(m.current+1) == 'N') && (((m.current + 1) == m.last) ||
 		// or at the end of a word but followed by suffixes
@@ -3431,7 +3848,7 @@ func (m *M3) test_MN() bool {
  * Call routines to encode "-MB-", in proper order
  *
  */
-func (m *M3) encode_MB() {
+func (m *metaph) encode_MB() {
 	if m.test_Silent_MB_1() {
 		if m.test_Pronounced_MB() {
 			m.current++
@@ -3460,7 +3877,7 @@ func (m *M3) encode_MB() {
  * Encode "-N-"
  *
  */
-func (m *M3) encode_N() {
+func (m *metaph) encode_N() {
 	if m.encode_NCE() {
 		return
 	}
@@ -3486,7 +3903,7 @@ func (m *M3) encode_N() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_NCE() bool {
+func (m *metaph) encode_NCE() bool {
 	//'acceptance', 'accountancy'
 	if m.stringAt((m.current+1), 1, "C", "S", "") && m.stringAt((m.current+2), 1, "E", "Y", "I", "") && (((m.current + 2) == m.last) || ((m.current+3) == m.last) && (m.charAt(m.current+3) == 'S')) {
 		m.metaphAdd("NTS", "NTS")
@@ -3501,7 +3918,7 @@ func (m *M3) encode_NCE() bool {
  * Encode "-P-"
  *
  */
-func (m *M3) encode_P() {
+func (m *metaph) encode_P() {
 	if m.encode_Silent_P_At_Beginning() || m.encode_PT() || m.encode_PH() || m.encode_PPH() || m.encode_RPS() || m.encode_COUP() || m.encode_PNEUM() || m.encode_PSYCH() || m.encode_PSALM() {
 		return
 	}
@@ -3517,7 +3934,7 @@ func (m *M3) encode_P() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_P_At_Beginning() bool {
+func (m *metaph) encode_Silent_P_At_Beginning() bool {
 	//skip these when at start of word
 	if (m.current == 0) && m.stringAt(m.current, 2, "PN", "PF", "PS", "PT", "") {
 		m.current += 1
@@ -3533,7 +3950,7 @@ func (m *M3) encode_Silent_P_At_Beginning() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PT() bool {
+func (m *metaph) encode_PT() bool {
 	// 'pterodactyl', 'receipt', 'asymptote'
 	if m.charAt(m.current+1) == 'T' {
 		if ((m.current == 0) && m.stringAt(m.current, 5, "PTERO", "")) || m.stringAt((m.current-5), 7, "RECEIPT", "") || m.stringAt((m.current-4), 8, "ASYMPTOT", "") {
@@ -3554,7 +3971,7 @@ func (m *M3) encode_PT() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PH() bool {
+func (m *metaph) encode_PH() bool {
 	if m.charAt(m.current+1) == 'H' {
 		// 'PH' silent in these contexts
 		if m.stringAt(m.current, 9, "PHTHALEIN", "") || ((m.current == 0) && m.stringAt(m.current, 4, "PHTH", "")) || m.stringAt((m.current-3), 10, "APOPHTHEGM", "") {
@@ -3584,7 +4001,7 @@ func (m *M3) encode_PH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PPH() bool {
+func (m *metaph) encode_PPH() bool {
 	// 'sappho'
 	if (m.charAt(m.current+1) == 'P') && ((m.current + 2) < m.length) && (m.charAt(m.current+2) == 'H') {
 		m.metaphAdd("F", "F")
@@ -3602,7 +4019,7 @@ func (m *M3) encode_PPH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_RPS() bool {
+func (m *metaph) encode_RPS() bool {
 	//'-corps-', 'corpsman'
 	if m.stringAt((m.current-3), 5, "CORPS", "") && !m.stringAt((m.current-3), 6, "CORPSE", "") {
 		m.current += 2
@@ -3619,7 +4036,7 @@ func (m *M3) encode_RPS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_COUP() bool {
+func (m *metaph) encode_COUP() bool {
 	//'coup'
 	if (m.current == m.last) && m.stringAt((m.current-3), 4, "COUP", "") && !m.stringAt((m.current-5), 6, "RECOUP", "") {
 		m.current++
@@ -3636,7 +4053,7 @@ func (m *M3) encode_COUP() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PNEUM() bool {
+func (m *metaph) encode_PNEUM() bool {
 	//'-pneum-'
 	if m.stringAt((m.current + 1), 4, "NEUM", "") {
 		m.metaphAdd("N", "N")
@@ -3655,7 +4072,7 @@ func (m *M3) encode_PNEUM() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PSYCH() bool {
+func (m *metaph) encode_PSYCH() bool {
 	//'-psych-'
 	if m.stringAt((m.current + 1), 4, "SYCH", "") {
 		if m.encodeVowels {
@@ -3678,7 +4095,7 @@ func (m *M3) encode_PSYCH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_PSALM() bool {
+func (m *metaph) encode_PSALM() bool {
 	//'-psalm-'
 	if m.stringAt((m.current + 1), 4, "SALM", "") {
 		// go ahead and encode entire word
@@ -3699,7 +4116,7 @@ func (m *M3) encode_PSALM() bool {
  * Eat redundant 'B' or 'P'
  *
  */
-func (m *M3) encode_PB() {
+func (m *metaph) encode_PB() {
 	// e.g. "campbell", "raspberry"
 	// eat redundant 'P' or 'B'
 	if m.stringAt((m.current + 1), 1, "P", "B", "") {
@@ -3713,7 +4130,7 @@ func (m *M3) encode_PB() {
  * Encode "-Q-"
  *
  */
-func (m *M3) encode_Q() {
+func (m *metaph) encode_Q() {
 	// current pinyin
 	if m.stringAt(m.current, 3, "QIN", "") {
 		m.metaphAdd("X", "X")
@@ -3735,7 +4152,7 @@ func (m *M3) encode_Q() {
  * Encode "-R-"
  *
  */
-func (m *M3) encode_R() {
+func (m *metaph) encode_R() {
 	if m.encode_RZ() {
 		return
 	}
@@ -3761,7 +4178,7 @@ func (m *M3) encode_R() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_RZ() bool {
+func (m *metaph) encode_RZ() bool {
 	if m.stringAt((m.current-2), 4, "GARZ", "KURZ", "MARZ", "MERZ", "HERZ", "PERZ", "WARZ", "") || m.stringAt(m.current, 5, "RZANO", "RZOLA", "") || m.stringAt((m.current-1), 4, "ARZA", "ARZN", "") {
 		return false
 	}
@@ -3806,7 +4223,7 @@ func (m *M3) encode_RZ() bool {
  * @return true if 'R' is silent in this context
  *
  */
-func (m *M3) test_Silent_R() bool {
+func (m *metaph) test_Silent_R() bool {
 	// test cases where 'R' is silent, either because the
 	// word is from the french or because it is no longer pronounced.
 	// e.g. "rogier", "monsieur", "surburban"
@@ -3838,7 +4255,7 @@ func (m *M3) test_Silent_R() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Vowel_RE_Transposition() bool {
+func (m *metaph) encode_Vowel_RE_Transposition() bool {
 	// -re inversion is just like
 	// -le inversion
 	// e.g. "fibre" => FABAR or "centre" => SANTAR
@@ -3854,7 +4271,7 @@ func (m *M3) encode_Vowel_RE_Transposition() bool {
  * Encode "-S-"
  *
  */
-func (m *M3) encode_S() {
+func (m *metaph) encode_S() {
 	if m.encode_SKJ() || m.encode_Special_SW() || m.encode_SJ() || m.encode_Silent_French_S_Final() || m.encode_Silent_French_S_Internal() || m.encode_ISL() || m.encode_STL() || m.encode_Christmas() || m.encode_STHM() || m.encode_ISTEN() || m.encode_Sugar() || m.encode_SH() || m.encode_SCH() || m.encode_SUR() || m.encode_SU() || m.encode_SSIO() || m.encode_SS() || m.encode_SIA() || m.encode_SIO() || m.encode_Anglicisations() || m.encode_SC() || m.encode_SEA_SUI_SIER() || m.encode_SEA() {
 		return
 	}
@@ -3876,7 +4293,7 @@ func (m *M3) encode_S() {
  * @return true if handled
  *
  */
-func (m *M3) encode_Special_SW() bool {
+func (m *metaph) encode_Special_SW() bool {
 	if m.current == 0 {
 		//
 		if m.names_Beginning_With_SW_That_Get_Alt_SV() {
@@ -3903,7 +4320,7 @@ func (m *M3) encode_Special_SW() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SKJ() bool {
+func (m *metaph) encode_SKJ() bool {
 	// scandinavian
 	if m.stringAt(m.current, 4, "SKJO", "SKJU", "") && isVowel(m.charAt(m.current+3)) {
 		m.metaphAdd("X", "X")
@@ -3920,7 +4337,7 @@ func (m *M3) encode_SKJ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SJ() bool {
+func (m *metaph) encode_SJ() bool {
 	if m.stringAt(0, 2, "SJ", "") {
 		m.metaphAdd("X", "X")
 		m.current += 2
@@ -3937,7 +4354,7 @@ func (m *M3) encode_SJ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_French_S_Final() bool {
+func (m *metaph) encode_Silent_French_S_Final() bool {
 	// "louis" is an exception because it gets two pronuncuations
 	if m.stringAt(0, 5, "LOUIS", "") && (m.current == m.last) {
 		m.metaphAdd("S", "")
@@ -3962,7 +4379,7 @@ func (m *M3) encode_Silent_French_S_Final() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_French_S_Internal() bool {
+func (m *metaph) encode_Silent_French_S_Internal() bool {
 	// french words familiar to americans where internal s is silent
 	if m.stringAt((m.current-2), 9, "DESCARTES", "") || m.stringAt((m.current-2), 7, "DESCHAM", "DESPRES", "DESROCH", "DESROSI", "DESJARD", "DESMARA",
 		"DESCHEN", "DESHOTE", "DESLAUR", "") || m.stringAt((m.current-2), 6, "MESNES", "") || m.stringAt((m.current-5), 8, "DUQUESNE", "DUCHESNE", "") || m.stringAt((m.current-7), 10, "BEAUCHESNE", "") || m.stringAt((m.current-3), 7, "FRESNEL", "") || m.stringAt((m.current-3), 9, "GROSVENOR", "") || m.stringAt((m.current-4), 10, "LOUISVILLE", "") || m.stringAt((m.current-7), 10, "ILLINOISAN", "") {
@@ -3979,7 +4396,7 @@ func (m *M3) encode_Silent_French_S_Internal() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ISL() bool {
+func (m *metaph) encode_ISL() bool {
 	//special cases 'island', 'isle', 'carlisle', 'carlysle'
 	if (m.stringAt((m.current-2), 4, "LISL", "LYSL", "AISL", "") && !m.stringAt((m.current-3), 7, "PAISLEY", "BAISLEY", "ALISLAM", "ALISLAH", "ALISLAA", "")) || ((m.current == 1) && ((m.stringAt((m.current-1), 4, "ISLE", "") || m.stringAt((m.current-1), 5, "ISLAN", "")) && !m.stringAt((m.current-1), 5, "ISLEY", "ISLER", ""))) {
 		m.current++
@@ -3996,7 +4413,7 @@ func (m *M3) encode_ISL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_STL() bool {
+func (m *metaph) encode_STL() bool {
 	//'hustle', 'bustle', 'whistle'
 	if m.stringAt(m.current, 4, "STLE", "STLI", "") && !m.stringAt((m.current+2), 4, "LESS", "LIKE", "LINE", "") || m.stringAt((m.current-3), 7, "THISTLY", "BRISTLY", "GRISTLY", "") ||
 		// e.g. "corpuscle"
@@ -4027,7 +4444,7 @@ func (m *M3) encode_STL() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Christmas() bool {
+func (m *metaph) encode_Christmas() bool {
 	//'christmas'
 	if m.stringAt((m.current - 4), 8, "CHRISTMA", "") {
 		m.metaphAdd("SM", "SM")
@@ -4045,7 +4462,7 @@ func (m *M3) encode_Christmas() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_STHM() bool {
+func (m *metaph) encode_STHM() bool {
 	//'asthma', 'isthmus'
 	if m.stringAt(m.current, 4, "STHM", "") {
 		m.metaphAdd("SM", "SM")
@@ -4063,7 +4480,7 @@ func (m *M3) encode_STHM() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ISTEN() bool {
+func (m *metaph) encode_ISTEN() bool {
 	// 't' is silent in verb, pronounced in name
 	if m.stringAt(0, 8, "CHRISTEN", "") {
 		// the word itself
@@ -4093,7 +4510,7 @@ func (m *M3) encode_ISTEN() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Sugar() bool {
+func (m *metaph) encode_Sugar() bool {
 	//special case 'sugar-'
 	if m.stringAt(m.current, 5, "SUGAR", "") {
 		m.metaphAdd("X", "X")
@@ -4112,7 +4529,7 @@ func (m *M3) encode_Sugar() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SH() bool {
+func (m *metaph) encode_SH() bool {
 	if m.stringAt(m.current, 2, "SH", "") {
 		// exception
 		if m.stringAt((m.current - 2), 8, "CASHMERE", "") {
@@ -4163,7 +4580,7 @@ func (m *M3) encode_SH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SCH() bool {
+func (m *metaph) encode_SCH() bool {
 	// these words were combining forms many centuries ago
 	if m.stringAt((m.current + 1), 2, "CH", "") {
 		if (m.current > 0) &&
@@ -4206,7 +4623,7 @@ func (m *M3) encode_SCH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SUR() bool {
+func (m *metaph) encode_SUR() bool {
 	// 'erasure', 'usury'
 	if m.stringAt((m.current + 1), 3, "URE", "URA", "URY", "") {
 		//'sure', 'ensure'
@@ -4231,7 +4648,7 @@ func (m *M3) encode_SUR() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SU() bool {
+func (m *metaph) encode_SU() bool {
 	//'sensuous', 'consensual'
 	if m.stringAt((m.current+1), 2, "UO", "UA", "") && (m.current != 0) {
 		// exceptions e.g. "persuade"
@@ -4259,7 +4676,7 @@ func (m *M3) encode_SU() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SSIO() bool {
+func (m *metaph) encode_SSIO() bool {
 	if m.stringAt((m.current + 1), 4, "SION", "") {
 		//"abcission"
 		if m.stringAt((m.current - 2), 2, "CI", "") {
@@ -4285,7 +4702,7 @@ func (m *M3) encode_SSIO() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SS() bool {
+func (m *metaph) encode_SS() bool {
 	// e.g. "russian", "pressure"
 	if m.stringAt((m.current-1), 5, "USSIA", "ESSUR", "ISSUR", "ISSUE", "") ||
 		// e.g. "hessian", "assurance"
@@ -4305,7 +4722,7 @@ func (m *M3) encode_SS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SIA() bool {
+func (m *metaph) encode_SIA() bool {
 	// e.g. "controversial", also "fuchsia", "ch" is silent
 	if m.stringAt((m.current-2), 5, "CHSIA", "") || m.stringAt((m.current-1), 5, "RSIAL", "") {
 		m.metaphAdd("X", "X")
@@ -4343,7 +4760,7 @@ func (m *M3) encode_SIA() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SIO() bool {
+func (m *metaph) encode_SIO() bool {
 	// special case, irish name
 	if m.stringAt(0, 7, "SIOBHAN", "") {
 		m.metaphAdd("X", "X")
@@ -4374,7 +4791,7 @@ func (m *M3) encode_SIO() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Anglicisations() bool {
+func (m *metaph) encode_Anglicisations() bool {
 	//german & anglicisations, e.g. 'smith' match 'schmidt', 'snider' match 'schneider'
 	//also, -sz- in slavic language altho in hungarian it is pronounced 's'
 	if ((m.current == 0) && m.stringAt((m.current+1), 1, "M", "N", "L", "")) || m.stringAt((m.current+1), 1, "Z", "") {
@@ -4400,7 +4817,7 @@ func (m *M3) encode_Anglicisations() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SC() bool {
+func (m *metaph) encode_SC() bool {
 	if m.stringAt(m.current, 2, "SC", "") {
 		// exception 'viscount'
 		if m.stringAt((m.current - 2), 8, "VISCOUNT", "") {
@@ -4443,7 +4860,7 @@ func (m *M3) encode_SC() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SEA_SUI_SIER() bool {
+func (m *metaph) encode_SEA_SUI_SIER() bool {
 	// "nausea" by itself has => NJ as a more likely encoding. Other forms
 	// using "nause-" (see encode_SEA()) have X or S as more familiar pronounciations
 	if (m.stringAt((m.current-3), 6, "NAUSEA", "") && ((m.current + 2) == m.last)) ||
@@ -4463,7 +4880,7 @@ func (m *M3) encode_SEA_SUI_SIER() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_SEA() bool {
+func (m *metaph) encode_SEA() bool {
 	if (m.stringAt(0, 4, "SEAN", "") && ((m.current + 3) == m.last)) || (m.stringAt((m.current-3), 6, "NAUSEO", "") && !m.stringAt((m.current-3), 7, "NAUSEAT", "")) {
 		m.metaphAdd("X", "X")
 		m.advanceCounter(3, 1)
@@ -4477,7 +4894,7 @@ func (m *M3) encode_SEA() bool {
  * Encode "-T-"
  *
  */
-func (m *M3) encode_T() {
+func (m *metaph) encode_T() {
 	if m.encode_T_Initial() || m.encode_TCH() || m.encode_Silent_French_T() || m.encode_TUN_TUL_TUA_TUO() || m.encode_TUE_TEU_TEOU_TUL_TIE() || m.encode_TUR_TIU_Suffixes() || m.encode_TI() || m.encode_TIENT() || m.encode_TSCH() || m.encode_TZSCH() || m.encode_TH_Pronounced_Separately() || m.encode_TTH() || m.encode_TH() {
 		return
 	}
@@ -4498,7 +4915,7 @@ func (m *M3) encode_T() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_T_Initial() bool {
+func (m *metaph) encode_T_Initial() bool {
 	if m.current == 0 {
 		// americans usually pronounce "tzar" as "zar"
 		if m.stringAt((m.current + 1), 3, "SAR", "ZAR", "") {
@@ -4544,7 +4961,7 @@ func (m *M3) encode_T_Initial() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TCH() bool {
+func (m *metaph) encode_TCH() bool {
 	if m.stringAt((m.current + 1), 2, "CH", "") {
 		m.metaphAdd("X", "X")
 		m.current += 3
@@ -4561,7 +4978,7 @@ func (m *M3) encode_TCH() bool {
  * @return true if encoding handled in this routine, false if not
  * TOUCHET CHABOT BENOIT
  */
-func (m *M3) encode_Silent_French_T() bool {
+func (m *metaph) encode_Silent_French_T() bool {
 	// french silent T familiar to americans
 	if ((m.current == m.last) && m.stringAt((m.current-4), 5, "MONET", "GENET", "CHAUT", "")) || m.stringAt((m.current-2), 9, "POTPOURRI", "") || m.stringAt((m.current-3), 9, "BOATSWAIN", "") || m.stringAt((m.current-3), 8, "MORTGAGE", "") || (m.stringAt((m.current-4), 5, "BERET", "BIDET", "FILET", "DEBUT", "DEPOT", "PINOT", "TAROT", "") || m.stringAt((m.current-5), 6, "BALLET", "BUFFET", "CACHET", "CHALET", "ESPRIT", "RAGOUT", "GOULET",
 		"CHABOT", "BENOIT", "") || m.stringAt((m.current-6), 7, "GOURMET", "BOUQUET", "CROCHET", "CROQUET", "PARFAIT", "PINCHOT",
@@ -4580,7 +4997,7 @@ func (m *M3) encode_Silent_French_T() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TUN_TUL_TUA_TUO() bool {
+func (m *metaph) encode_TUN_TUL_TUA_TUO() bool {
 	// e.g. "fortune", "fortunate"
 	if m.stringAt((m.current-3), 6, "FORTUN", "") ||
 		// e.g. "capitulate"
@@ -4604,7 +5021,7 @@ func (m *M3) encode_TUN_TUL_TUA_TUO() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TUE_TEU_TEOU_TUL_TIE() bool {
+func (m *metaph) encode_TUE_TEU_TEOU_TUL_TIE() bool {
 	// 'constituent', 'pasteur'
 	if m.stringAt((m.current+1), 4, "UENT", "") || m.stringAt((m.current-4), 9, "RIGHTEOUS", "") || m.stringAt((m.current-3), 7, "STATUTE", "") || m.stringAt((m.current-3), 7, "AMATEUR", "") ||
 		// e.g. "blastula", "pasteur"
@@ -4632,7 +5049,7 @@ func (m *M3) encode_TUE_TEU_TEOU_TUL_TIE() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TUR_TIU_Suffixes() bool {
+func (m *metaph) encode_TUR_TIU_Suffixes() bool {
 	// 'adventure', 'musculature'
 	if (m.current > 0) && m.stringAt((m.current+1), 3, "URE", "URA", "URI", "URY", "URO", "IUS", "") {
 		// exceptions e.g. 'tessitura', mostly from romance languages
@@ -4661,7 +5078,7 @@ func (m *M3) encode_TUR_TIU_Suffixes() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TI() bool {
+func (m *metaph) encode_TI() bool {
 	// '-tio-', '-tia-', '-tiu-'
 	// except combining forms where T already pronounced e.g 'rooseveltian'
 	if (m.stringAt((m.current+1), 2, "IO", "") && !m.stringAt((m.current-1), 5, "ETIOL", "")) || m.stringAt((m.current+1), 3, "IAL", "") || m.stringAt((m.current-1), 5, "RTIUM", "ATIUM", "") || ((m.stringAt((m.current+1), 3, "IAN", "") && (m.current > 0)) && !(m.stringAt((m.current-4), 8, "FAUSTIAN", "") || m.stringAt((m.current-5), 9, "PROUSTIAN", "") || m.stringAt((m.current-2), 7, "TATIANA", "") || (m.stringAt((m.current-3), 7, "KANTIAN", "GENTIAN", "") || m.stringAt((m.current-8), 12, "ROOSEVELTIAN", ""))) || (((m.current + 2) == m.last) && m.stringAt(m.current, 3, "TIA", "") &&
@@ -4695,7 +5112,7 @@ func (m *M3) encode_TI() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TIENT() bool {
+func (m *metaph) encode_TIENT() bool {
 	// e.g. 'patient'
 	if m.stringAt((m.current + 1), 4, "IENT", "") {
 		m.metaphAdd("X", "T")
@@ -4712,7 +5129,7 @@ func (m *M3) encode_TIENT() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TSCH() bool {
+func (m *metaph) encode_TSCH() bool {
 	//'deutsch'
 	if m.stringAt(m.current, 4, "TSCH", "") &&
 		// combining forms in german where the 'T' is pronounced seperately
@@ -4734,7 +5151,7 @@ func (m *M3) encode_TSCH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TZSCH() bool {
+func (m *metaph) encode_TZSCH() bool {
 	//'neitzsche'
 	if m.stringAt(m.current, 5, "TZSCH", "") {
 		m.metaphAdd("X", "X")
@@ -4754,7 +5171,7 @@ func (m *M3) encode_TZSCH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TH_Pronounced_Separately() bool {
+func (m *metaph) encode_TH_Pronounced_Separately() bool {
 	//'adulthood', 'bithead', 'apartheid'
 	if ((m.current > 0) && m.stringAt((m.current+1), 4, "HOOD", "HEAD", "HEID", "HAND", "HILL", "HOLD",
 		"HAWK", "HEAP", "HERD", "HOLE", "HOOK", "HUNT",
@@ -4782,7 +5199,7 @@ func (m *M3) encode_TH_Pronounced_Separately() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TTH() bool {
+func (m *metaph) encode_TTH() bool {
 	// 'matthew' vs. 'outthink'
 	if m.stringAt(m.current, 3, "TTH", "") {
 		if m.stringAt((m.current - 2), 5, "MATTH", "") {
@@ -4804,7 +5221,7 @@ func (m *M3) encode_TTH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_TH() bool {
+func (m *metaph) encode_TH() bool {
 	if m.stringAt(m.current, 2, "TH", "") {
 		//'-clothes-'
 		if m.stringAt((m.current - 3), 7, "CLOTHES", "") {
@@ -4839,7 +5256,7 @@ func (m *M3) encode_TH() bool {
  * Encode "-V-"
  *
  */
-func (m *M3) encode_V() {
+func (m *metaph) encode_V() {
 	// eat redundant 'V'
 	if m.charAt(m.current+1) == 'V' {
 		m.current += 2
@@ -4854,7 +5271,7 @@ func (m *M3) encode_V() {
  * Encode "-W-"
  *
  */
-func (m *M3) encode_W() {
+func (m *metaph) encode_W() {
 	if m.encode_Silent_W_At_Beginning() || m.encode_WITZ_WICZ() || m.encode_WR() || m.encode_Initial_W_Vowel() || m.encode_WH() || m.encode_Eastern_European_W() {
 		return
 	}
@@ -4875,7 +5292,7 @@ func (m *M3) encode_W() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Silent_W_At_Beginning() bool {
+func (m *metaph) encode_Silent_W_At_Beginning() bool {
 	//skip these when at start of word
 	if (m.current == 0) && m.stringAt(m.current, 2, "WR", "") {
 		m.current += 1
@@ -4895,11 +5312,11 @@ func (m *M3) encode_Silent_W_At_Beginning() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_WITZ_WICZ() bool {
+func (m *metaph) encode_WITZ_WICZ() bool {
 	//polish e.g. 'filipowicz'
 	if ((m.current + 3) == m.last) && m.stringAt(m.current, 4, "WICZ", "WITZ", "") {
 		if m.encodeVowels {
-			if (m.primary.Len() > 0) && m.primary.String()[m.primary.Len()-1] == 'A' {
+			if (len(m.primary) > 0) && m.primary[len(m.primary)-1] == 'A' {
 				m.metaphAdd("TS", "FAX")
 			} else {
 				m.metaphAdd("ATS", "FAX")
@@ -4920,7 +5337,7 @@ func (m *M3) encode_WITZ_WICZ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_WR() bool {
+func (m *metaph) encode_WR() bool {
 	//can also be in middle of word
 	if m.stringAt(m.current, 2, "WR", "") {
 		m.metaphAdd("R", "R")
@@ -4939,7 +5356,7 @@ func (m *M3) encode_WR() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_W_Vowel() bool {
+func (m *metaph) encode_Initial_W_Vowel() bool {
 	if (m.current == 0) && isVowel(m.charAt(m.current+1)) {
 		//Witter should match Vitter
 		if m.germanic_Or_Slavic_Name_Beginning_With_W() {
@@ -4968,7 +5385,7 @@ func (m *M3) encode_Initial_W_Vowel() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_WH() bool {
+func (m *metaph) encode_WH() bool {
 	if m.stringAt(m.current, 2, "WH", "") {
 		// cases where it is pronounced as H
 		// e.g. 'who', 'whole'
@@ -5008,7 +5425,7 @@ func (m *M3) encode_WH() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Eastern_European_W() bool {
+func (m *metaph) encode_Eastern_European_W() bool {
 	//Arnow should match Arnoff
 	if ((m.current == m.last) && isVowel(m.charAt(m.current-1))) || m.stringAt((m.current-1), 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY", "") || (m.stringAt(m.current, 5, "WICKI", "WACKI", "") && ((m.current + 4) == m.last)) || m.stringAt(m.current, 4, "WIAK", "") && ((m.current+3) == m.last) || m.stringAt(0, 3, "SCH", "") {
 		m.metaphAddExactApprox4("", "V", "", "F")
@@ -5023,7 +5440,7 @@ func (m *M3) encode_Eastern_European_W() bool {
  * Encode "-X-"
  *
  */
-func (m *M3) encode_X() {
+func (m *metaph) encode_X() {
 	if m.encode_Initial_X() || m.encode_Greek_X() || m.encode_X_Special_Cases() || m.encode_X_To_H() || m.encode_X_Vowel() || m.encode_French_X_Final() {
 		return
 	}
@@ -5044,7 +5461,7 @@ func (m *M3) encode_X() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Initial_X() bool {
+func (m *metaph) encode_Initial_X() bool {
 	// current chinese pinyin spelling
 	if m.stringAt(0, 3, "XIA", "XIO", "XIE", "") || m.stringAt(0, 2, "XU", "") {
 		m.metaphAdd("X", "X")
@@ -5068,7 +5485,7 @@ func (m *M3) encode_Initial_X() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_Greek_X() bool {
+func (m *metaph) encode_Greek_X() bool {
 	// 'xylophone', xylem', 'xanthoma', 'xeno-'
 	if m.stringAt((m.current+1), 3, "YLO", "YLE", "ENO", "") || m.stringAt((m.current+1), 4, "ANTH", "") {
 		m.metaphAdd("S", "S")
@@ -5085,7 +5502,7 @@ func (m *M3) encode_Greek_X() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_X_Special_Cases() bool {
+func (m *metaph) encode_X_Special_Cases() bool {
 	// 'luxury'
 	if m.stringAt((m.current - 2), 5, "LUXUR", "") {
 		m.metaphAddExactApprox("GJ", "KJ")
@@ -5110,7 +5527,7 @@ func (m *M3) encode_X_Special_Cases() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_X_To_H() bool {
+func (m *metaph) encode_X_To_H() bool {
 	// TODO: look for other mexican indian words
 	// where 'X' is usually pronounced this way
 	if m.stringAt((m.current-2), 6, "OAXACA", "") || m.stringAt((m.current-3), 7, "QUIXOTE", "") {
@@ -5130,7 +5547,7 @@ func (m *M3) encode_X_To_H() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_X_Vowel() bool {
+func (m *metaph) encode_X_Vowel() bool {
 	// e.g. "sexual", "connexion" (british), "noxious"
 	if m.stringAt((m.current + 1), 3, "UAL", "ION", "IOU", "") {
 		m.metaphAdd("KX", "KS")
@@ -5148,7 +5565,7 @@ func (m *M3) encode_X_Vowel() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_French_X_Final() bool {
+func (m *metaph) encode_French_X_Final() bool {
 	//french e.g. "breaux", "paix"
 	if !((m.current == m.last) && (m.stringAt((m.current-3), 3, "IAU", "EAU", "IEU", "") || m.stringAt((m.current-2), 2, "AI", "AU", "OU", "OI", "EU", ""))) {
 		m.metaphAdd("KS", "KS")
@@ -5161,7 +5578,7 @@ func (m *M3) encode_French_X_Final() bool {
  * Encode "-Z-"
  *
  */
-func (m *M3) encode_Z() {
+func (m *metaph) encode_Z() {
 	if m.encode_ZZ() || m.encode_ZU_ZIER_ZS() || m.encode_French_EZ() || m.encode_German_Z() {
 		return
 	}
@@ -5187,7 +5604,7 @@ func (m *M3) encode_Z() {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ZZ() bool {
+func (m *metaph) encode_ZZ() bool {
 	// "abruzzi", 'pizza'
 	if (m.charAt(m.current+1) == 'Z') && ((m.stringAt((m.current+2), 1, "I", "O", "A", "") && ((m.current + 2) == m.last)) || m.stringAt((m.current-2), 9, "MOZZARELL", "PIZZICATO", "PUZZONLAN", "")) {
 		m.metaphAdd("TS", "S")
@@ -5204,7 +5621,7 @@ func (m *M3) encode_ZZ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ZU_ZIER_ZS() bool {
+func (m *metaph) encode_ZU_ZIER_ZS() bool {
 	if ((m.current == 1) && m.stringAt((m.current-1), 4, "AZUR", "")) || (m.stringAt(m.current, 4, "ZIER", "") && !m.stringAt((m.current-2), 6, "VIZIER", "")) || m.stringAt(m.current, 3, "ZSA", "") {
 		m.metaphAdd("J", "S")
 
@@ -5226,7 +5643,7 @@ func (m *M3) encode_ZU_ZIER_ZS() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_French_EZ() bool {
+func (m *metaph) encode_French_EZ() bool {
 	if ((m.current == 3) && m.stringAt((m.current-3), 4, "CHEZ", "")) || m.stringAt((m.current-5), 6, "RENDEZ", "") {
 		m.current++
 		return true
@@ -5242,10 +5659,10 @@ func (m *M3) encode_French_EZ() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_German_Z() bool {
+func (m *metaph) encode_German_Z() bool {
 	if ((m.current == 2) && ((m.current + 1) == m.last) && m.stringAt((m.current-2), 4, "NAZI", "")) || m.stringAt((m.current-2), 6, "NAZIFY", "MOZART", "") || m.stringAt((m.current-3), 4, "HOLZ", "HERZ", "MERZ", "FITZ", "") || (m.stringAt((m.current-3), 4, "GANZ", "") && !isVowel(m.charAt(m.current+1))) || m.stringAt((m.current-4), 5, "STOLZ", "PRINZ", "") || m.stringAt((m.current-4), 7, "VENEZIA", "") || m.stringAt((m.current-3), 6, "HERZOG", "") ||
 		// german words beginning with "sch-" but not schlimazel, schmooze
-		(strings.Contains(m.inWord, "SCH") && !(m.stringAt((m.last - 2), 3, "IZE", "OZE", "ZEL", ""))) || ((m.current > 0) && m.stringAt(m.current, 4, "ZEIT", "")) || m.stringAt((m.current-3), 4, "WEIZ", "") {
+		(m.contains("SCH") && !(m.stringAt((m.last - 2), 3, "IZE", "OZE", "ZEL", ""))) || ((m.current > 0) && m.stringAt(m.current, 4, "ZEIT", "")) || m.stringAt((m.current-3), 4, "WEIZ", "") {
 		if (m.current > 0) && m.charAt(m.current-1) == 'T' {
 			m.metaphAdd("S", "S")
 		} else {
@@ -5264,7 +5681,7 @@ func (m *M3) encode_German_Z() bool {
  * @return true if encoding handled in this routine, false if not
  *
  */
-func (m *M3) encode_ZH() bool {
+func (m *metaph) encode_ZH() bool {
 	//chinese pinyin e.g. 'zhao', also english "phonetic spelling"
 	if m.charAt(m.current+1) == 'H' {
 		m.metaphAdd("J", "J")
@@ -5283,13 +5700,8 @@ func (m *M3) encode_ZH() bool {
  *
  * @return true if swedish, dutch, or slavic derived name
  */
-func (m *M3) names_Beginning_With_SW_That_Get_Alt_SV() bool {
-	if m.stringAt(0, 7, "SWANSON", "SWENSON", "SWINSON", "SWENSEN",
-		"SWOBODA", "") || m.stringAt(0, 9, "SWIDERSKI", "SWARTHOUT", "") || m.stringAt(0, 10, "SWEARENGIN", "") {
-		return true
-	}
-
-	return false
+func (m *metaph) names_Beginning_With_SW_That_Get_Alt_SV() bool {
+	return m.startsWith(m.exceptions.SWAltSV)
 }
 
 /**
@@ -5300,13 +5712,8 @@ func (m *M3) names_Beginning_With_SW_That_Get_Alt_SV() bool {
  *
  * @return true if german derived name
  */
-func (m *M3) names_Beginning_With_SW_That_Get_Alt_XV() bool {
-	if m.stringAt(0, 5, "SWART", "") || m.stringAt(0, 6, "SWARTZ", "SWARTS", "SWIGER", "") || m.stringAt(0, 7, "SWITZER", "SWANGER", "SWIGERT",
-		"SWIGART", "SWIHART", "") || m.stringAt(0, 8, "SWEITZER", "SWATZELL", "SWINDLER", "") || m.stringAt(0, 9, "SWINEHART", "") || m.stringAt(0, 10, "SWEARINGEN", "") {
-		return true
-	}
-
-	return false
+func (m *metaph) names_Beginning_With_SW_That_Get_Alt_XV() bool {
+	return m.startsWith(m.exceptions.SWAltXV)
 }
 
 /**
@@ -5317,62 +5724,8 @@ func (m *M3) names_Beginning_With_SW_That_Get_Alt_XV() bool {
  *
  * @return true if germanic or slavic name
  */
-func (m *M3) germanic_Or_Slavic_Name_Beginning_With_W() bool {
-	if m.stringAt(0, 3, "WEE", "WIX", "WAX", "") || m.stringAt(0, 4, "WOLF", "WEIS", "WAHL", "WALZ", "WEIL", "WERT",
-		"WINE", "WILK", "WALT", "WOLL", "WADA", "WULF",
-		"WEHR", "WURM", "WYSE", "WENZ", "WIRT", "WOLK",
-		"WEIN", "WYSS", "WASS", "WANN", "WINT", "WINK",
-		"WILE", "WIKE", "WIER", "WELK", "WISE", "") || m.stringAt(0, 5, "WIRTH", "WIESE", "WITTE", "WENTZ", "WOLFF", "WENDT",
-		"WERTZ", "WILKE", "WALTZ", "WEISE", "WOOLF", "WERTH",
-		"WEESE", "WURTH", "WINES", "WARGO", "WIMER", "WISER",
-		"WAGER", "WILLE", "WILDS", "WAGAR", "WERTS", "WITTY",
-		"WIENS", "WIEBE", "WIRTZ", "WYMER", "WULFF", "WIBLE",
-		"WINER", "WIEST", "WALKO", "WALLA", "WEBRE", "WEYER",
-		"WYBLE", "WOMAC", "WILTZ", "WURST", "WOLAK", "WELKE",
-		"WEDEL", "WEIST", "WYGAN", "WUEST", "WEISZ", "WALCK",
-		"WEITZ", "WYDRA", "WANDA", "WILMA", "WEBER", "") || m.stringAt(0, 6, "WETZEL", "WEINER", "WENZEL", "WESTER", "WALLEN", "WENGER",
-		"WALLIN", "WEILER", "WIMMER", "WEIMER", "WYRICK", "WEGNER",
-		"WINNER", "WESSEL", "WILKIE", "WEIGEL", "WOJCIK", "WENDEL",
-		"WITTER", "WIENER", "WEISER", "WEXLER", "WACKER", "WISNER",
-		"WITMER", "WINKLE", "WELTER", "WIDMER", "WITTEN", "WINDLE",
-		"WASHER", "WOLTER", "WILKEY", "WIDNER", "WARMAN", "WEYANT",
-		"WEIBEL", "WANNER", "WILKEN", "WILTSE", "WARNKE", "WALSER",
-		"WEIKEL", "WESNER", "WITZEL", "WROBEL", "WAGNON", "WINANS",
-		"WENNER", "WOLKEN", "WILNER", "WYSONG", "WYCOFF", "WUNDER",
-		"WINKEL", "WIDMAN", "WELSCH", "WEHNER", "WEIGLE", "WETTER",
-		"WUNSCH", "WHITTY", "WAXMAN", "WILKER", "WILHAM", "WITTIG",
-		"WITMAN", "WESTRA", "WEHRLE", "WASSER", "WILLER", "WEGMAN",
-		"WARFEL", "WYNTER", "WERNER", "WAGNER", "WISSER", "") || m.stringAt(0, 7, "WISEMAN", "WINKLER", "WILHELM", "WELLMAN", "WAMPLER", "WACHTER",
-		"WALTHER", "WYCKOFF", "WEIDNER", "WOZNIAK", "WEILAND", "WILFONG",
-		"WIEGAND", "WILCHER", "WIELAND", "WILDMAN", "WALDMAN", "WORTMAN",
-		"WYSOCKI", "WEIDMAN", "WITTMAN", "WIDENER", "WOLFSON", "WENDELL",
-		"WEITZEL", "WILLMAN", "WALDRUP", "WALTMAN", "WALCZAK", "WEIGAND",
-		"WESSELS", "WIDEMAN", "WOLTERS", "WIREMAN", "WILHOIT", "WEGENER",
-		"WOTRING", "WINGERT", "WIESNER", "WAYMIRE", "WHETZEL", "WENTZEL",
-		"WINEGAR", "WESTMAN", "WYNKOOP", "WALLICK", "WURSTER", "WINBUSH",
-		"WILBERT", "WALLACH", "WYNKOOP", "WALLICK", "WURSTER", "WINBUSH",
-		"WILBERT", "WALLACH", "WEISSER", "WEISNER", "WINDERS", "WILLMON",
-		"WILLEMS", "WIERSMA", "WACHTEL", "WARNICK", "WEIDLER", "WALTRIP",
-		"WHETSEL", "WHELESS", "WELCHER", "WALBORN", "WILLSEY", "WEINMAN",
-		"WAGAMAN", "WOMMACK", "WINGLER", "WINKLES", "WIEDMAN", "WHITNER",
-		"WOLFRAM", "WARLICK", "WEEDMAN", "WHISMAN", "WINLAND", "WEESNER",
-		"WARTHEN", "WETZLER", "WENDLER", "WALLNER", "WOLBERT", "WITTMER",
-		"WISHART", "WILLIAM", "") || m.stringAt(0, 8, "WESTPHAL", "WICKLUND", "WEISSMAN", "WESTLUND", "WOLFGANG", "WILLHITE",
-		"WEISBERG", "WALRAVEN", "WOLFGRAM", "WILHOITE", "WECHSLER", "WENDLING",
-		"WESTBERG", "WENDLAND", "WININGER", "WHISNANT", "WESTRICK", "WESTLING",
-		"WESTBURY", "WEITZMAN", "WEHMEYER", "WEINMANN", "WISNESKI", "WHELCHEL",
-		"WEISHAAR", "WAGGENER", "WALDROUP", "WESTHOFF", "WIEDEMAN", "WASINGER",
-		"WINBORNE", "") || m.stringAt(0, 9, "WHISENANT", "WEINSTEIN", "WESTERMAN", "WASSERMAN", "WITKOWSKI", "WEINTRAUB",
-		"WINKELMAN", "WINKFIELD", "WANAMAKER", "WIECZOREK", "WIECHMANN", "WOJTOWICZ",
-		"WALKOWIAK", "WEINSTOCK", "WILLEFORD", "WARKENTIN", "WEISINGER", "WINKLEMAN",
-		"WILHEMINA", "") || m.stringAt(0, 10, "WISNIEWSKI", "WUNDERLICH", "WHISENHUNT", "WEINBERGER", "WROBLEWSKI",
-		"WAGUESPACK", "WEISGERBER", "WESTERVELT", "WESTERLUND", "WASILEWSKI",
-		"WILDERMUTH", "WESTENDORF", "WESOLOWSKI", "WEINGARTEN", "WINEBARGER",
-		"WESTERBERG", "WANNAMAKER", "WEISSINGER", "") || m.stringAt(0, 11, "WALDSCHMIDT", "WEINGARTNER", "WINEBRENNER", "") || m.stringAt(0, 12, "WOLFENBARGER", "") || m.stringAt(0, 13, "WOJCIECHOWSKI", "") {
-		return true
-	}
-
-	return false
+func (m *metaph) germanic_Or_Slavic_Name_Beginning_With_W() bool {
+	return m.startsWith(m.exceptions.GermanicOrSlavicW)
 }
 
 /**
@@ -5388,64 +5741,6 @@ func (m *M3) germanic_Or_Slavic_Name_Beginning_With_W() bool {
  * @return true if name starting with 'J' that
  * should get an alternate encoding as a vowel
  */
-func (m *M3) names_Beginning_With_J_That_Get_Alt_Y() bool {
-	if m.stringAt(0, 3, "JAN", "JON", "JAN", "JIN", "JEN", "") || m.stringAt(0, 4, "JUHL", "JULY", "JOEL", "JOHN", "JOSH",
-		"JUDE", "JUNE", "JONI", "JULI", "JENA",
-		"JUNG", "JINA", "JANA", "JENI", "JOEL",
-		"JANN", "JONA", "JENE", "JULE", "JANI",
-		"JONG", "JOHN", "JEAN", "JUNG", "JONE",
-		"JARA", "JUST", "JOST", "JAHN", "JACO",
-		"JANG", "JUDE", "JONE", "") || m.stringAt(0, 5, "JOANN", "JANEY", "JANAE", "JOANA", "JUTTA",
-		"JULEE", "JANAY", "JANEE", "JETTA", "JOHNA",
-		"JOANE", "JAYNA", "JANES", "JONAS", "JONIE",
-		"JUSTA", "JUNIE", "JUNKO", "JENAE", "JULIO",
-		"JINNY", "JOHNS", "JACOB", "JETER", "JAFFE",
-		"JESKE", "JANKE", "JAGER", "JANIK", "JANDA",
-		"JOSHI", "JULES", "JANTZ", "JEANS", "JUDAH",
-		"JANUS", "JENNY", "JENEE", "JONAH", "JONAS",
-		"JACOB", "JOSUE", "JOSEF", "JULES", "JULIE",
-		"JULIA", "JANIE", "JANIS", "JENNA", "JANNA",
-		"JEANA", "JENNI", "JEANE", "JONNA", "") || m.stringAt(0, 6, "JORDAN", "JORDON", "JOSEPH", "JOSHUA", "JOSIAH",
-		"JOSPEH", "JUDSON", "JULIAN", "JULIUS", "JUNIOR",
-		"JUDITH", "JOESPH", "JOHNIE", "JOANNE", "JEANNE",
-		"JOANNA", "JOSEFA", "JULIET", "JANNIE", "JANELL",
-		"JASMIN", "JANINE", "JOHNNY", "JEANIE", "JEANNA",
-		"JOHNNA", "JOELLE", "JOVITA", "JOSEPH", "JONNIE",
-		"JANEEN", "JANINA", "JOANIE", "JAZMIN", "JOHNIE",
-		"JANENE", "JOHNNY", "JONELL", "JENELL", "JANETT",
-		"JANETH", "JENINE", "JOELLA", "JOEANN", "JULIAN",
-		"JOHANA", "JENICE", "JANNET", "JANISE", "JULENE",
-		"JOSHUA", "JANEAN", "JAIMEE", "JOETTE", "JANYCE",
-		"JENEVA", "JORDAN", "JACOBS", "JENSEN", "JOSEPH",
-		"JANSEN", "JORDON", "JULIAN", "JAEGER", "JACOBY",
-		"JENSON", "JARMAN", "JOSLIN", "JESSEN", "JAHNKE",
-		"JACOBO", "JULIEN", "JOSHUA", "JEPSON", "JULIUS",
-		"JANSON", "JACOBI", "JUDSON", "JARBOE", "JOHSON",
-		"JANZEN", "JETTON", "JUNKER", "JONSON", "JAROSZ",
-		"JENNER", "JAGGER", "JASMIN", "JEPSEN", "JORDEN",
-		"JANNEY", "JUHASZ", "JERGEN", "JAKOB", "") || m.stringAt(0, 7, "JOHNSON", "JOHNNIE", "JASMINE", "JEANNIE", "JOHANNA",
-		"JANELLE", "JANETTE", "JULIANA", "JUSTINA", "JOSETTE",
-		"JOELLEN", "JENELLE", "JULIETA", "JULIANN", "JULISSA",
-		"JENETTE", "JANETTA", "JOSELYN", "JONELLE", "JESENIA",
-		"JANESSA", "JAZMINE", "JEANENE", "JOANNIE", "JADWIGA",
-		"JOLANDA", "JULIANE", "JANUARY", "JEANICE", "JANELLA",
-		"JEANETT", "JENNINE", "JOHANNE", "JOHNSIE", "JANIECE",
-		"JOHNSON", "JENNELL", "JAMISON", "JANSSEN", "JOHNSEN",
-		"JARDINE", "JAGGERS", "JURGENS", "JOURDAN", "JULIANO",
-		"JOSEPHS", "JHONSON", "JOZWIAK", "JANICKI", "JELINEK",
-		"JANSSON", "JOACHIM", "JANELLE", "JACOBUS", "JENNING",
-		"JANTZEN", "JOHNNIE", "") || m.stringAt(0, 8, "JOSEFINA", "JEANNINE", "JULIANNE", "JULIANNA", "JONATHAN",
-		"JONATHON", "JEANETTE", "JANNETTE", "JEANETTA", "JOHNETTA",
-		"JENNEFER", "JULIENNE", "JOSPHINE", "JEANELLE", "JOHNETTE",
-		"JULIEANN", "JOSEFINE", "JULIETTA", "JOHNSTON", "JACOBSON",
-		"JACOBSEN", "JOHANSEN", "JOHANSON", "JAWORSKI", "JENNETTE",
-		"JELLISON", "JOHANNES", "JASINSKI", "JUERGENS", "JARNAGIN",
-		"JEREMIAH", "JEPPESEN", "JARNIGAN", "JANOUSEK", "") || m.stringAt(0, 9, "JOHNATHAN", "JOHNATHON", "JORGENSEN", "JEANMARIE", "JOSEPHINA",
-		"JEANNETTE", "JOSEPHINE", "JEANNETTA", "JORGENSON", "JANKOWSKI",
-		"JOHNSTONE", "JABLONSKI", "JOSEPHSON", "JOHANNSEN", "JURGENSEN",
-		"JIMMERSON", "JOHANSSON", "") || m.stringAt(0, 10, "JAKUBOWSKI", "") {
-		return true
-	}
-
-	return false
+func (m *metaph) names_Beginning_With_J_That_Get_Alt_Y() bool {
+	return m.startsWith(m.exceptions.JAltY)
 }
@@ -0,0 +1,320 @@
+package metaphone3
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/**
+ * Words whose default keys changed when stringAt stopped comparing
+ * one character more than asked for (and so only matched at the end
+ * of the word). The old keys are those of the port before that fix;
+ * the new ones follow the Java reference, where stringAt compares
+ * exactly 'length' characters.
+ */
+var stringAtChanges = []struct {
+	word                     string
+	oldPrimary, oldAlternate string
+	primary, alternate       string
+}{
+	{"Smith", "SM0", "", "SM0", "XMT"},
+	{"Williams", "ALMS", "", "ALMS", "FLMS"},
+	{"Jones", "JNS", "", "JNS", "ANS"},
+	{"Garcia", "KRK", "", "KRS", ""},
+	{"Jackson", "JKKSN", "", "JKSN", ""},
+	{"White", "HT", "", "AT", ""},
+	{"Sanchez", "SNKS", "", "SNXS", "SNKS"},
+	{"Walker", "ALKR", "", "AKR", ""},
+	{"Nguyen", "NKN", "", "NN", ""},
+	{"Campbell", "KMPPL", "", "KMPL", ""},
+	{"Mitchell", "MTKL", "", "MXL", ""},
+	{"Schmidt", "SKMT", "", "XMT", ""},
+	{"Schneider", "SKNTR", "", "XNTR", ""},
+	{"Fischer", "FSKR", "", "FXR", "FSKR"},
+	{"Becker", "PKKR", "", "PKR", ""},
+	{"Schulz", "SKLTS", "", "XLTS", ""},
+	{"Richter", "RKTR", "", "RKTR", "RXTR"},
+	{"Schroeder", "SKRTR", "", "XRTR", ""},
+	{"Schwarz", "SKRTS", "", "XRTS", "XFRTS"},
+	{"Schmitt", "SKMT", "", "XMT", ""},
+	{"Wojciechowski", "AJKKSK", "FJKKFSK", "ASKSK", "FSXFSK"},
+	{"Szymanski", "SSMNSK", "", "SMNSK", "XMNSK"},
+	{"Krawczyk", "KRKSK", "", "KRXK", ""},
+	{"Michalski", "MKLSK", "", "MKLSK", "MXLSK"},
+	{"Nowicki", "NKK", "NFKK", "NK", "NFSK"},
+	{"Adamczyk", "ATMKSK", "", "ATMXK", ""},
+	{"Wieczorek", "AKSRK", "FKSRK", "AXRK", "FXRK"},
+	{"Majewski", "MJSK", "MJFSK", "MJSK", "MFSK"},
+	{"Olszewski", "ALSSSK", "ALSSFSK", "ALSSK", "ALXFSK"},
+	{"Walczak", "ALKSK", "FLKSK", "ALXK", "FLXK"},
+	{"Michalak", "MKLK", "", "MKLK", "MXLK"},
+	{"Szewczyk", "SSKSK", "", "SXK", "XXK"},
+	{"Tomaszewski", "TMSSSK", "TMSSFSK", "TMSSK", "TMXFSK"},
+	{"Pietrzak", "PTRSK", "", "PTRSK", "PTXK"},
+	{"Marciniak", "MRKNK", "", "MRSNK", ""},
+	{"Chmielewski", "KMLSK", "KMLFSK", "KMLSK", "XMLFSK"},
+	{"Wlodarczyk", "LTRKSK", "", "LTRXK", ""},
+	{"Czarnecki", "KSRNKK", "", "SRNK", "SRNSK"},
+	{"Sawicki", "SKK", "SFKK", "SK", "SFSK"},
+	{"Maciejewski", "MKJSK", "MKJFSK", "MSJSK", "MXFSK"},
+	{"Szczepanski", "SSKSPNSK", "", "SXPNSK", "XXPNSK"},
+	{"Kucharski", "KKRSK", "", "KXRSK", "KKRSK"},
+	{"Wysocki", "ASKK", "FSKK", "ASK", "FSSK"},
+	{"Kazmierczak", "KSMRKSK", "", "KSMRXK", ""},
+	{"Sobczak", "SPKSK", "", "SPXK", ""},
+	{"Czerwinski", "KSRNSK", "", "XRNSK", ""},
+	{"Andrzejewski", "ANTRSJSK", "ANTRSJFS", "ANTRSJSK", "ANTJFSK"},
+	{"Cieslak", "KSLK", "", "SSLK", ""},
+	{"Glowacki", "KLKK", "KLFKK", "KLK", "KLFSK"},
+	{"Zakrzewski", "SKRSSK", "SKRSFSK", "SKRSSK", "SKXFSK"},
+	{"Krajewski", "KRJSK", "KRJFSK", "KRJSK", "KRFSK"},
+	{"Gajewski", "KJSK", "KJFSK", "KJSK", "KFSK"},
+	{"Szymczak", "SSMKSK", "", "SMXK", "XMXK"},
+	{"Szulc", "SSLK", "", "SLK", "XLK"},
+	{"Przybylski", "PRSPLSK", "", "PRSPLSK", "PXPLSK"},
+	{"Caesar", "KSR", "", "SSR", ""},
+	{"Chianti", "KNT", "", "KNT", "XNT"},
+	{"Bacchus", "PKKS", "", "PKS", ""},
+	{"Gnocchi", "KNKK", "", "NK", ""},
+	{"Tchaikovsky", "TKKFSK", "", "XKFSK", ""},
+	{"Czerny", "KSRN", "", "XRN", ""},
+	{"Guillermo", "KLRM", "", "KRM", ""},
+	{"Villasenor", "FLSNR", "", "FLSNR", "FSNR"},
+	{"Thumb", "TM", "", "0M", ""},
+	{"Cough", "KK", "", "KF", ""},
+	{"Laugh", "LK", "", "LF", ""},
+	{"Hugh", "HK", "", "H", ""},
+	{"Bough", "PK", "", "P", ""},
+	{"Island", "ASLNT", "", "ALNT", ""},
+	{"Xian", "SN", "", "XN", ""},
+	{"Qing", "KNK", "", "XNK", ""},
+	{"Giovanni", "KFN", "JFN", "JFN", "KFN"},
+	{"Gianni", "KN", "JN", "JN", "KN"},
+	{"Signor", "SKNR", "", "SNR", "SKNR"},
+}
+
+func TestStringAtChanges(t *testing.T) {
+	m := New()
+	for _, c := range stringAtChanges {
+		primary, alternate := m.Encode(c.word)
+		if primary != c.primary || alternate != c.alternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q (before the stringAt fix: %q, %q)",
+				c.word, primary, alternate, c.primary, c.alternate, c.oldPrimary, c.oldAlternate)
+		}
+	}
+}
+
+/**
+ * Checks stringAt against the plain definition the reference uses,
+ * that the length letters at start are one of the strings, at every
+ * position of every corpus word: with the letters found there, with
+ * one of them changed, cut short or run on, and with a non-ASCII
+ * letter in place of the last one.
+ */
+func TestStringAtMatchesSubstring(t *testing.T) {
+	m := New().getMetaph()
+	defer metaphPool.Put(m)
+
+	for _, word := range exceptionTestWords(t) {
+		m.loadWord(word)
+		for start := -1; start <= m.length; start++ {
+			for length := 1; length <= 4; length++ {
+				var found string
+				if start >= 0 && start+length <= m.length {
+					found = string(m.inWord[start : start+length])
+				}
+				candidates := []string{"AB", "É"}
+				if found != "" {
+					runes := []rune(found)
+					last := len(runes) - 1
+					candidates = append(candidates, found, found[:len(found)-1], found+"S",
+						string(runes[:last])+"Q", string(runes[:last])+"Ñ")
+				}
+
+				for _, c := range candidates {
+					want := found != "" && c == found
+					if got := m.stringAt(start, length, c); got != want {
+						t.Errorf("%q: stringAt(%d, %d, %q) = %t, want %t", word, start, length, c, got, want)
+					}
+				}
+				if got := m.stringAt(start, length, candidates...); got != (found != "") {
+					t.Errorf("%q: stringAt(%d, %d, %q) = %t, want %t", word, start, length, candidates, got, found != "")
+				}
+			}
+		}
+	}
+}
+
+/**
+ * Returns encoders for the four combinations of
+ * encoding vowels and encoding exact.
+ */
+func encodersForModes(t testing.TB, opts ...Option) []*M3 {
+	var encoders []*M3
+	for _, vowels := range []bool{false, true} {
+		for _, exact := range []bool{false, true} {
+			m, err := NewWithOptions(append([]Option{WithEncodeVowels(vowels), WithEncodeExact(exact)}, opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoders = append(encoders, m)
+		}
+	}
+	return encoders
+}
+
+func TestEncodeAccentedLikeUnaccented(t *testing.T) {
+	// the accented letters here are coded like their base letters,
+	// so any difference means the input was misindexed after a
+	// multi-byte character
+	for _, pair := range [][2]string{
+		{"Muñoz", "Munoz"}, {"Müller", "Muller"}, {"Gómez", "Gomez"},
+		{"Strauß", "Strauss"}, {"Renée", "Renee"}, {"Fernández", "Fernandez"},
+		{"Ordóñez", "Ordonez"}, {"Désirée", "Desiree"}, {"Görtz", "Gortz"},
+		{"Schön", "Schon"}, {"Ångström", "Angstrom"}, {"Mañana", "Manana"},
+		{"Ibáñez", "Ibanez"}, {"Müllerschmidt", "Mullerschmidt"},
+		{"Sánchez", "Sanchez"}, {"Schröder", "Schroder"},
+	} {
+		for _, m := range encodersForModes(t) {
+			p1, a1 := m.Encode(pair[0])
+			p2, a2 := m.Encode(pair[1])
+			if p1 != p2 || a1 != a2 {
+				t.Errorf("%v: Encode(%q) = %q, %q but Encode(%q) = %q, %q",
+					m.Config(), pair[0], p1, a1, pair[1], p2, a2)
+			}
+		}
+	}
+}
+
+func TestEncodeAccented(t *testing.T) {
+	m := New()
+	for _, c := range []struct{ word, primary, alternate string }{
+		{"Muñoz", "MNS", ""},
+		{"Núñez", "NNS", ""},
+		{"José", "JS", ""},
+		{"Straße", "STRS", ""},
+		{"Françoise", "FRNSS", ""},
+		{"Çelik", "SLK", ""},
+		{"Garçon", "KRSN", ""},
+		{"Björk", "PJRK", ""},
+		{"Søren", "SRN", ""},
+		{"Dvořák", "TFRK", "TFRJK"},
+		{"Łukasz", "LKS", "LKX"},
+		{"Škoda", "XKT", ""},
+		{"Žižek", "SSK", ""},
+		{"Čapek", "XPK", ""},
+		{"Ðorđe", "0RT", ""},
+		{"Þór", "0R", ""},
+		{"Æsir", "ASR", ""},
+	} {
+		primary, alternate := m.Encode(c.word)
+		if primary != c.primary || alternate != c.alternate {
+			t.Errorf("Encode(%q) = %q, %q, want %q, %q", c.word, primary, alternate, c.primary, c.alternate)
+		}
+	}
+}
+
+/**
+ * Shares each encoder between goroutines that encode the same words
+ * starting at different words, and checks that Encode, EncodeKey and Explain
+ * give what they give serially. Run with -race to check that no call
+ * writes state another call reads.
+ */
+func TestConcurrentEncodeMatchesSerial(t *testing.T) {
+	words := append(benchmarkNames(500), "Wojciechowski", "Schwarzenegger", "Müller", "Straße", "")
+	type result struct {
+		primary, alternate string
+		key                Key
+		steps              []Step
+	}
+
+	for _, m := range encodersForModes(t, WithKeyLength(4)) {
+		want := make([]result, len(words))
+		for i, word := range words {
+			want[i].primary, want[i].alternate = m.Encode(word)
+			want[i].key = m.EncodeKey(word)
+			want[i].steps = m.Explain(word)
+		}
+
+		const goroutines = 8
+		var wg sync.WaitGroup
+		errs := make(chan string, goroutines)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for n := range words {
+					i := (n + g*len(words)/goroutines) % len(words)
+					var got result
+					got.primary, got.alternate = m.Encode(words[i])
+					got.key = m.EncodeKey(words[i])
+					got.steps = m.Explain(words[i])
+					if !reflect.DeepEqual(got, want[i]) {
+						errs <- fmt.Sprintf("%v: goroutine %d: %q gives %+v, serially %+v", m.Config(), g, words[i], got, want[i])
+						return
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	}
+}
+
+/** Syllables benchmarkNames builds names from. */
+var benchmarkSyllables = strings.Fields(`
+	an ber bran ca chen ci cz dor e fer ga gue hel ja jo ka ki
+	ko kow la le lo ma mo mü na ne ni no ño pe pi ra re ri ro
+	sa sch se ski so ston ta te ti ton tz vi wa wi ya yo za zy`)
+
+/**
+ * Returns n made-up names of two to four syllables, the same
+ * ones on every call.
+ */
+func benchmarkNames(n int) []string {
+	names := make([]string, n)
+	seed := uint32(1)
+	next := func(k int) int {
+		seed = seed*1664525 + 1013904223
+		return int(seed>>16) % k
+	}
+
+	for i := range names {
+		var b strings.Builder
+		for j := 2 + next(3); j > 0; j-- {
+			b.WriteString(benchmarkSyllables[next(len(benchmarkSyllables))])
+		}
+		name := b.String()
+		names[i] = strings.ToUpper(name[:1]) + name[1:]
+	}
+	return names
+}
+
+func BenchmarkEncode(b *testing.B) {
+	m := New()
+	names := benchmarkNames(10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			m.Encode(name)
+		}
+	}
+}
+
+func BenchmarkEncodeLongWord(b *testing.B) {
+	m := New()
+	m.SetKeyLength(MAX_KEY_ALLOCATION)
+	word := strings.Repeat("Wojciechowski", 20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Encode(word)
+	}
+}
@@ -0,0 +1,262 @@
+package metaphone3
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * Particles that may stand in front of a surname, upper cased,
+ * longest first so that "VAN DER" is preferred over "VAN".
+ */
+var nameParticles = [][]string{
+	{"VAN", "DER"}, {"VAN", "DEN"}, {"VAN", "DE"},
+	{"VON", "DER"}, {"VON", "DEM"}, {"VON", "DEN"},
+	{"DE", "LA"}, {"DE", "LAS"}, {"DE", "LOS"}, {"DE", "LO"},
+	{"VAN"}, {"VON"}, {"DE"}, {"DEL"}, {"DELLA"}, {"DELLE"}, {"DEI"},
+	{"ST"}, {"STE"}, {"SAINT"}, {"SAINTE"},
+}
+
+/**
+ * Particles that are as often names of their own, e.g. the
+ * Vietnamese "Le" or the Chinese "Du". Only tried when
+ * Config.AmbiguousParticles is set.
+ */
+var ambiguousParticles = [][]string{
+	{"DES"}, {"DU"}, {"DI"}, {"DA"}, {"DOS"}, {"DAS"},
+	{"LA"}, {"LE"}, {"TEN"}, {"TER"},
+	{"MAC"}, {"MC"},
+}
+
+/**
+ * Particles written as part of the surname, e.g. "Vandermeer",
+ * each with the least number of letters that must follow it.
+ */
+var attachedParticles = []struct {
+	prefix string
+	rest   int
+}{
+	{"VANDER", 3}, {"VANDEN", 3}, {"VONDER", 3},
+	{"O'", 2}, {"O’", 2}, {"D'", 2}, {"D’", 2}, {"L'", 2}, {"L’", 2},
+	{"MC", 2},
+}
+
+/**
+ * Spellings of a particle that are encoded the same way.
+ */
+var particleSpellings = map[string]string{
+	"ST":  "SAINT",
+	"STE": "SAINTE",
+	"MC":  "MAC",
+	"O’":  "O'",
+	"D’":  "D'",
+	"L’":  "L'",
+}
+
+/**
+ * NameKey is the result of EncodeName.
+ */
+type NameKey struct {
+	/** Keys of the name with each particle joined to the word
+	 * that follows it, e.g. for "van der Meer" the keys of
+	 * "VANDERMEER". */
+	Primary string `json:"primary"`
+
+	/** Secondary key matching Primary. Empty if the same as
+	 * Primary. */
+	Alternate string `json:"alternate"`
+
+	/** Keys of the name without its particles, e.g. for
+	 * "van der Meer" the keys of "MEER". Empty if the name
+	 * has no particles. */
+	BarePrimary string `json:"barePrimary"`
+
+	/** Secondary key matching BarePrimary. Empty if the same as
+	 * BarePrimary. */
+	BareAlternate string `json:"bareAlternate"`
+}
+
+/**
+ * Returns the distinct, non-empty keys of k, to be indexed
+ * or looked up. Two names match if they share a key.
+ */
+func (k NameKey) Keys() []string {
+	var keys []string
+	for _, key := range []string{k.Primary, k.Alternate, k.BarePrimary, k.BareAlternate} {
+		if key == "" {
+			continue
+		}
+
+		dup := false
+		for _, seen := range keys {
+			if key == seen {
+				dup = true
+				break
+			}
+		}
+
+		if !dup {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+/**
+ * Encodes a personal name, recognising surname particles such as
+ * "van der", "von", "de la", "O'", "McDonald"/"MacDonald" and
+ * "St."/"Saint". Words such as "Le", "Di" or "Mac" written apart
+ * are only taken for particles if Config.AmbiguousParticles is set.
+ *
+ * A particle is encoded joined to the word that follows it, in one
+ * spelling ("St John" and "Saint John" both as "SAINTJOHN",
+ * "Vandermeer" and "van der Meer" both as "VANDERMEER"), and the
+ * name is also encoded without its particles, so that names can be
+ * matched either way. The name is split into words as EncodePhrase
+ * does, and the keys of the words are concatenated.
+ * Safe for concurrent use by multiple goroutines.
+ */
+func (m *M3) EncodeName(name string) NameKey {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	var words []string
+	m.config.tokenize(name, func(token string, span Span) {
+		words = append(words, token)
+	})
+
+	var with, bare []string
+	found := false
+	for i := 0; i < len(words); {
+		if n := matchParticle(words[i:], m.config.AmbiguousParticles); n > 0 {
+			word := strings.ToUpper(words[i+n])
+			with = append(with, joinParticle(words[i:i+n])+word)
+			bare = append(bare, word)
+			found = true
+			i += n + 1
+			continue
+		}
+
+		word := strings.ToUpper(words[i])
+		if particle, rest, ok := splitParticle(words[i]); ok {
+			with = append(with, particle+rest)
+			bare = append(bare, rest)
+			found = true
+		} else {
+			with = append(with, word)
+			bare = append(bare, word)
+		}
+		i++
+	}
+
+	var k NameKey
+	k.Primary, k.Alternate = s.encodeWords(with)
+	if found {
+		k.BarePrimary, k.BareAlternate = s.encodeWords(bare)
+	}
+	return k
+}
+
+/**
+ * Encodes each of words and concatenates the keys.
+ *
+ * @return the primary keys, and the secondary keys (or primary
+ * keys where there are none) if different
+ */
+func (m *metaph) encodeWords(words []string) (primary, secondary string) {
+	var p, a strings.Builder
+	for _, word := range words {
+		wp, wa := m.encode(word)
+		p.WriteString(wp)
+		if wa == "" {
+			wa = wp
+		}
+		a.WriteString(wa)
+	}
+
+	primary = p.String()
+	if a.String() != primary {
+		secondary = a.String()
+	}
+	return primary, secondary
+}
+
+/**
+ * Tests whether words begin with a particle followed by
+ * at least one more word.
+ *
+ * @param ambiguous whether to try ambiguousParticles too
+ * @return number of words in the particle, 0 if none
+ */
+func matchParticle(words []string, ambiguous bool) int {
+	particles := nameParticles
+	if ambiguous {
+		particles = append(particles[:len(particles):len(particles)], ambiguousParticles...)
+	}
+
+	for _, particle := range particles {
+		if len(particle) >= len(words) {
+			continue
+		}
+
+		match := true
+		for i, p := range particle {
+			if strings.TrimSuffix(strings.ToUpper(words[i]), ".") != p {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return len(particle)
+		}
+	}
+	return 0
+}
+
+/**
+ * Joins the words of a particle in its usual spelling.
+ */
+func joinParticle(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		p := strings.TrimSuffix(strings.ToUpper(word), ".")
+		if spelling, ok := particleSpellings[p]; ok {
+			p = spelling
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+/**
+ * Splits a particle written as part of word off its front,
+ * e.g. "O'Brien" into "O'" and "BRIEN". "Mac" only counts as
+ * a particle when the next letter is upper case, as in
+ * "MacDonald" but not "Mackintosh" or "Machado".
+ *
+ * @return the particle in its usual spelling, the upper cased
+ * rest of word, and whether word starts with a particle
+ */
+func splitParticle(word string) (particle, rest string, ok bool) {
+	upper := strings.ToUpper(word)
+
+	for _, a := range attachedParticles {
+		if strings.HasPrefix(upper, a.prefix) && utf8.RuneCountInString(upper[len(a.prefix):]) >= a.rest {
+			particle = a.prefix
+			if spelling, ok := particleSpellings[particle]; ok {
+				particle = spelling
+			}
+			return particle, upper[len(a.prefix):], true
+		}
+	}
+
+	if strings.HasPrefix(upper, "MAC") && len(word) > 3 && utf8.RuneCountInString(upper[3:]) >= 3 {
+		if r, _ := utf8.DecodeRuneInString(word[3:]); unicode.IsUpper(r) {
+			return "MAC", upper[3:], true
+		}
+	}
+
+	return "", "", false
+}
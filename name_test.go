@@ -0,0 +1,81 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeName(t *testing.T) {
+	plain := New()
+	ambiguous, err := NewWithOptions(WithAmbiguousParticles(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vanDerMeer := NameKey{Primary: "FNTRMR", BarePrimary: "MR"}
+	saintJohn := NameKey{Primary: "SNTJN", BarePrimary: "JN", BareAlternate: "AN"}
+	oBrien := NameKey{Primary: "APRN", BarePrimary: "PRN"}
+	macDonald := NameKey{Primary: "MKTNLT", BarePrimary: "TNLT"}
+
+	for _, test := range []struct {
+		m    *M3
+		name string
+		want NameKey
+	}{
+		{plain, "van der Meer", vanDerMeer},
+		{plain, "Vandermeer", vanDerMeer},
+		{plain, "VAN DER MEER", vanDerMeer},
+		{plain, "St. John", saintJohn},
+		{plain, "St John", saintJohn},
+		{plain, "Saint John", saintJohn},
+		{plain, "O'Brien", oBrien},
+		{plain, "O’Brien", oBrien},
+		{plain, "McDonald", macDonald},
+		{plain, "MacDonald", macDonald},
+		{plain, "de la Cruz", NameKey{Primary: "TLKRS", BarePrimary: "KRS"}},
+		// "Mac" only counts before an upper case letter
+		{plain, "Mackintosh", NameKey{Primary: "MKNTX"}},
+
+		// "Le" is the family name, only "Van" is a particle
+		{plain, "Le Van Thanh", NameKey{Primary: "LFN0N", BarePrimary: "LTN"}},
+		{ambiguous, "Le Van Thanh", NameKey{Primary: "LFNTN", BarePrimary: "FNTN"}},
+
+		// written apart, "Mc" and "Mac" are joined to the name
+		// but only stripped if AmbiguousParticles is set
+		{plain, "Mc Donald", NameKey{Primary: "MKTNLT"}},
+		{plain, "Mac Donald", NameKey{Primary: "MKTNLT"}},
+		{ambiguous, "Mc Donald", macDonald},
+		{ambiguous, "Mac Donald", macDonald},
+		{ambiguous, "McDonald", macDonald},
+
+		{plain, "Di Maria", NameKey{Primary: "TMR"}},
+		{ambiguous, "Di Maria", NameKey{Primary: "TMR", BarePrimary: "MR"}},
+		{ambiguous, "van der Meer", vanDerMeer},
+
+		// a particle needs a name after it
+		{ambiguous, "Le", NameKey{Primary: "L"}},
+		{plain, "Van", NameKey{Primary: "FN"}},
+		{plain, "", NameKey{}},
+	} {
+		if got := test.m.EncodeName(test.name); got != test.want {
+			t.Errorf("AmbiguousParticles %t: EncodeName(%q) = %+v, want %+v",
+				test.m.Config().AmbiguousParticles, test.name, got, test.want)
+		}
+	}
+}
+
+func TestNameKeyKeys(t *testing.T) {
+	for _, test := range []struct {
+		k    NameKey
+		want []string
+	}{
+		{NameKey{Primary: "SNTJN", BarePrimary: "JN", BareAlternate: "AN"}, []string{"SNTJN", "JN", "AN"}},
+		{NameKey{Primary: "SM0", Alternate: "XMT", BarePrimary: "SM0"}, []string{"SM0", "XMT"}},
+		{NameKey{Primary: "MKNTX"}, []string{"MKNTX"}},
+		{NameKey{}, nil},
+	} {
+		if got := test.k.Keys(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%+v.Keys() = %q, want %q", test.k, got, test.want)
+		}
+	}
+}
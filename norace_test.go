@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package metaphone3
+
+/** Whether the race detector is on. See race_test.go. */
+const raceEnabled = false
@@ -0,0 +1,105 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	turkish := Normalization{Turkish: true}
+	ligatures := Normalization{ExpandLigatures: true}
+	strip := Normalization{StripAccents: true}
+	keep := Normalization{StripAccents: true, KeepAccented: "ÑÇ"}
+	fold := Normalization{Punctuation: PunctuationFold}
+	drop := Normalization{Punctuation: PunctuationDrop}
+	compose := Normalization{Compose: true}
+
+	for _, test := range []struct {
+		name    string
+		n       Normalization
+		in      string
+		want    string
+		offsets []int
+	}{
+		{"zero value", Normalization{}, "O’Brien-Æsir", "O’Brien-Æsir", nil},
+
+		{"width", Normalization{FoldWidth: true}, "Ｓｍｉｔｈ", "Smith", nil},
+
+		{"turkish i", turkish, "istanbul", "ISTANBUL", nil},
+		{"turkish dotless ı", turkish, "ılık", "ILIK", nil},
+		{"turkish İ", turkish, "İzmir", "IZMIR", nil},
+		{"turkish off", Normalization{}, "İzmir", "İzmir", nil},
+
+		{"Æ", ligatures, "Æsir", "AEsir", []int{0, 0, 1, 2, 3}},
+		{"Œ", ligatures, "Œuvre", "OEuvre", nil},
+		{"œ", ligatures, "cœur", "coeur", nil},
+		{"Ĳ", ligatures, "Ĳssel", "IJssel", nil},
+		{"ﬁ", ligatures, "ﬁsh", "fish", []int{0, 0, 1, 2}},
+		{"ﬄ", ligatures, "waﬄe", "waffle", nil},
+
+		{"strip", strip, "Muñoz Gómez Łódź", "Munoz Gomez Lodz", nil},
+		{"strip combining", strip, "Mu\u0308ller", "Muller", []int{0, 1, 3, 4, 5, 6}},
+		{"keep accented", keep, "Muñoz Françoise Gómez", "Muñoz Françoise Gomez", nil},
+		{"keep accented upper", keep, "MUÑOZ", "MUÑOZ", nil},
+
+		{"keep punctuation", Normalization{}, "O’Brien – Smith", "O’Brien – Smith", nil},
+		{"fold punctuation", fold, "O’Brien – Smith", "O'Brien - Smith", nil},
+		{"fold leaves ASCII", fold, "O'Brien-Smith", "O'Brien-Smith", nil},
+		{"drop punctuation", drop, "O’Brien – Smith", "OBrien  Smith", nil},
+		{"drop ASCII punctuation", drop, "O'Brien-Smith & Co.", "OBrienSmith  Co", nil},
+
+		{"NFD to NFC", compose, "Mu\u0308ller", "M\u00fcller", []int{0, 1, 3, 4, 5, 6}},
+		{"NFC unchanged", compose, "M\u00fcller", "M\u00fcller", nil},
+		{"NFD Vietnamese", compose, "Nguye\u0302\u0303n", "Nguy\u1ec5n", nil},
+		{"compose then strip", Normalization{Compose: true, StripAccents: true}, "Günther", "Gunther", nil},
+	} {
+		got, offsets := test.n.Normalize(nil, nil, []rune(test.in))
+		if string(got) != test.want {
+			t.Errorf("%s: %+v.Normalize(%q) = %q, want %q", test.name, test.n, test.in, string(got), test.want)
+		}
+		if len(offsets) != len(got) {
+			t.Errorf("%s: Normalize(%q) gave %d offsets for %d runes", test.name, test.in, len(offsets), len(got))
+		}
+		if test.offsets != nil && !reflect.DeepEqual(offsets, test.offsets) {
+			t.Errorf("%s: Normalize(%q) offsets %v, want %v", test.name, test.in, offsets, test.offsets)
+		}
+	}
+}
+
+/**
+ * Checks that each mode makes the encoder give the same keys for
+ * spellings it folds together, and that it does not fold the
+ * spellings it should leave apart.
+ */
+func TestNormalizationEncode(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		n    Normalization
+		a, b string
+		same bool
+	}{
+		{"turkish İ", Normalization{Turkish: true}, "İzmir", "Izmir", true},
+		{"turkish ı", Normalization{Turkish: true}, "Yıldız", "Yildiz", true},
+		{"ligature Œ", Normalization{ExpandLigatures: true}, "Œdipus", "Oedipus", true},
+		{"ligature ﬁ", Normalization{ExpandLigatures: true}, "ﬁsher", "fisher", true},
+		{"strip", Normalization{StripAccents: true}, "Łódź", "Lodz", true},
+		{"keep Ñ", Normalization{StripAccents: true, KeepAccented: "Ñ"}, "Muñoz", "Munyoz", true},
+		{"keep Ç", Normalization{StripAccents: true, KeepAccented: "Ç"}, "Garçon", "Garcon", false},
+		{"fold apostrophe", Normalization{Punctuation: PunctuationFold}, "O’Brien", "O'Brien", true},
+		{"drop apostrophe", Normalization{Punctuation: PunctuationDrop}, "O’Brien", "OBrien", true},
+		{"NFD", Normalization{Compose: true}, "Mun\u0303oz", "Mu\u00f1oz", true},
+		{"width", Normalization{FoldWidth: true}, "Ｓｍｉｔｈ", "Smith", true},
+	} {
+		m, err := NewWithOptions(WithNormalization(test.n))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pa, aa := m.Encode(test.a)
+		pb, ab := m.Encode(test.b)
+		if same := pa == pb && aa == ab; same != test.same {
+			t.Errorf("%s: Encode(%q) = %q, %q and Encode(%q) = %q, %q, want same %t",
+				test.name, test.a, pa, aa, test.b, pb, ab, test.same)
+		}
+	}
+}
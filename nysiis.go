@@ -0,0 +1,175 @@
+package metaphone3
+
+import (
+	"strings"
+)
+
+/** Length NYSIIS keys are truncated to by default, as in the original. */
+const DEFAULT_NYSIIS_LENGTH = 6
+
+/**
+ * NYSIIS encodes words with the New York State Identification and
+ * Intelligence System algorithm, which keeps more of the shape of a
+ * name than Soundex and writes its keys in letters, e.g.
+ *
+ *   "MACINTOSH" -> "MCANT", "KNUTH" -> "NAT", "PFEISTER" -> "FASTAR",
+ *   "SCHOENHOEFT" -> "SANAFT", "MCKNIGHT" -> "MCNAGT",
+ *   "WESTERLUND" -> "WASTAR" ("WASTARLAD" untruncated).
+ *
+ * Only the letters 'A' to 'Z' are coded; other characters are
+ * ignored.
+ *
+ * A NYSIIS is safe for concurrent use once configured.
+ */
+type NYSIIS struct {
+	/** Length keys are truncated to, or 0 for no limit. */
+	keyLength int
+}
+
+/**
+ * Constructor, default: DEFAULT_NYSIIS_LENGTH long keys.
+ */
+func NewNYSIIS() *NYSIIS { return &NYSIIS{keyLength: DEFAULT_NYSIIS_LENGTH} }
+
+/**
+ * Sets the length keys are truncated to; values below 1
+ * turn truncation off.
+ */
+func (n *NYSIIS) SetKeyLength(keyLength int) {
+	if keyLength < 0 {
+		keyLength = 0
+	}
+	n.keyLength = keyLength
+}
+
+/** Returns the length keys are truncated to, or 0 for no limit. */
+func (n *NYSIIS) KeyLength() int { return n.keyLength }
+
+/**
+ * Encodes word to its NYSIIS key. NYSIIS has no alternate key,
+ * so alternate is always empty.
+ */
+func (n *NYSIIS) Encode(word string) (primary, alternate string) {
+	chars := make([]byte, 0, len(word))
+	for _, r := range word {
+		if c, ok := soundexLetter(r); ok {
+			chars = append(chars, c)
+		}
+	}
+	if len(chars) == 0 {
+		return "", ""
+	}
+
+	chars = nysiisFirst(chars)
+	chars = nysiisLast(chars)
+
+	key := make([]byte, 1, len(chars))
+	key[0] = chars[0]
+
+	for i := 1; i < len(chars); i++ {
+		next, aNext := byte(' '), byte(' ')
+		if i+1 < len(chars) {
+			next = chars[i+1]
+		}
+		if i+2 < len(chars) {
+			aNext = chars[i+2]
+		}
+
+		copy(chars[i:], nysiisTranscode(chars[i-1], chars[i], next, aNext))
+		if chars[i] != chars[i-1] {
+			key = append(key, chars[i])
+		}
+	}
+
+	if len(key) > 1 {
+		if key[len(key)-1] == 'S' {
+			key = key[:len(key)-1]
+		}
+		last := key[len(key)-1]
+		if len(key) > 2 && key[len(key)-2] == 'A' && last == 'Y' {
+			key = append(key[:len(key)-2], 'Y')
+		}
+		if last == 'A' {
+			key = key[:len(key)-1]
+		}
+	}
+
+	if n.keyLength > 0 && len(key) > n.keyLength {
+		key = key[:n.keyLength]
+	}
+	return string(key), ""
+}
+
+/**
+ * Rewrites the start of the name: MAC -> MCC, KN -> NN, K -> C,
+ * PH and PF -> FF, SCH -> SSS.
+ */
+func nysiisFirst(chars []byte) []byte {
+	for _, t := range [...][2]string{
+		{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"},
+	} {
+		if strings.HasPrefix(string(chars), t[0]) {
+			copy(chars, t[1])
+			break
+		}
+	}
+	return chars
+}
+
+/**
+ * Rewrites the end of the name: EE and IE -> Y;
+ * DT, RT, RD, NT and ND -> D.
+ */
+func nysiisLast(chars []byte) []byte {
+	for _, t := range [...][2]string{
+		{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"},
+	} {
+		if strings.HasSuffix(string(chars), t[0]) {
+			chars = append(chars[:len(chars)-len(t[0])], t[1]...)
+		}
+	}
+	return chars
+}
+
+/**
+ * Returns what the letter curr, with prev before it and next and
+ * aNext after it, is rewritten to. The result may also replace the
+ * letters after curr.
+ */
+func nysiisTranscode(prev, curr, next, aNext byte) string {
+	switch {
+	case curr == 'E' && next == 'V':
+		return "AF"
+	case nysiisIsVowel(curr):
+		return "A"
+	case curr == 'Q':
+		return "G"
+	case curr == 'Z':
+		return "S"
+	case curr == 'M':
+		return "N"
+	case curr == 'K':
+		if next == 'N' {
+			return "NN"
+		}
+		return "C"
+	case curr == 'S' && next == 'C' && aNext == 'H':
+		return "SSS"
+	case curr == 'P' && next == 'H':
+		return "FF"
+	case curr == 'H' && (!nysiisIsVowel(prev) || !nysiisIsVowel(next)):
+		return string(prev)
+	case curr == 'W' && nysiisIsVowel(prev):
+		return string(prev)
+	}
+	return string(curr)
+}
+
+/** Tests whether c is one of the vowels NYSIIS knows. */
+func nysiisIsVowel(c byte) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+	return false
+}
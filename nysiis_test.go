@@ -0,0 +1,73 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+var nysiisTests = []struct {
+	word, key, full string
+}{
+	{"MACINTOSH", "MCANT", "MCANT"},
+	{"KNUTH", "NAT", "NAT"},
+	{"PFEISTER", "FASTAR", "FASTAR"},
+	{"SCHOENHOEFT", "SANAFT", "SANAFT"},
+	{"MCKNIGHT", "MCNAGT", "MCNAGT"},
+	{"WESTERLUND", "WASTAR", "WASTARLAD"},
+	{"Phillipson", "FALAPS", "FALAPSAN"},
+	{"Heitschmidt", "HATSNA", "HATSNAD"},
+	{"Knight", "NAGT", "NAGT"},
+	{"Lawrence", "LARANC", "LARANC"},
+	{"Mitchell", "MATCAL", "MATCAL"},
+	{"Brian", "BRAN", "BRAN"},
+	{"Brown", "BRAN", "BRAN"},
+	{"John", "JAN", "JAN"},
+	{"Jay", "JY", "JY"},
+	{"Larry", "LARY", "LARY"},
+	{"Kirby", "CARBY", "CARBY"},
+	{"Dean", "DAN", "DAN"},
+	{"Bart", "BAD", "BAD"},
+	{"Hart", "HAD", "HAD"},
+	{"Hayes", "HAY", "HAY"},
+	{"O'Daniel", "ODANAL", "ODANAL"},
+	{"", "", ""},
+	{"123", "", ""},
+}
+
+func TestNYSIIS(t *testing.T) {
+	n, full := NewNYSIIS(), NewNYSIIS()
+	full.SetKeyLength(0)
+
+	for _, test := range nysiisTests {
+		if key, alternate := n.Encode(test.word); key != test.key || alternate != "" {
+			t.Errorf("Encode(%q) = %q, %q, want %q", test.word, key, alternate, test.key)
+		}
+		if key, _ := full.Encode(test.word); key != test.full {
+			t.Errorf("untruncated Encode(%q) = %q, want %q", test.word, key, test.full)
+		}
+	}
+}
+
+func TestNYSIISKeyLength(t *testing.T) {
+	n := NewNYSIIS()
+	if got := n.KeyLength(); got != DEFAULT_NYSIIS_LENGTH {
+		t.Errorf("KeyLength() = %d, want %d", got, DEFAULT_NYSIIS_LENGTH)
+	}
+
+	for _, test := range []struct {
+		keyLength, want int
+		key             string
+	}{
+		{-1, 0, "WASTARLAD"},
+		{0, 0, "WASTARLAD"},
+		{3, 3, "WAS"},
+		{20, 20, "WASTARLAD"},
+	} {
+		n.SetKeyLength(test.keyLength)
+		if got := n.KeyLength(); got != test.want {
+			t.Errorf("SetKeyLength(%d): KeyLength() = %d, want %d", test.keyLength, got, test.want)
+		}
+		if key, _ := n.Encode("WESTERLUND"); key != test.key {
+			t.Errorf("SetKeyLength(%d): Encode(WESTERLUND) = %q, want %q", test.keyLength, key, test.key)
+		}
+	}
+}
@@ -0,0 +1,510 @@
+package metaphone3
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * Keys given to a word by an override table.
+ */
+type Override struct {
+	/** Primary key. */
+	Primary string `json:"primary"`
+
+	/** Alternate key, empty if there is none. */
+	Alternate string `json:"alternate"`
+}
+
+/** Index in overrideTable of entries that apply in every mode. */
+const allModes = 4
+
+/**
+ * Entries of an override table. Replaced as a whole when
+ * the table changes, so that lookups need no lock.
+ */
+type overrideTable struct {
+	/** Entries by mode, see overrideMode. */
+	modes [allModes + 1]map[string]Override
+
+	/** Sum of the overrideDigest of every entry. */
+	digest uint64
+}
+
+/**
+ * Adds an entry to t, replacing any entry for the same
+ * word and mode. The map of the mode must not be shared.
+ */
+func (t *overrideTable) put(mode int, word string, keys Override) {
+	if t.modes[mode] == nil {
+		t.modes[mode] = make(map[string]Override)
+	}
+	if old, ok := t.modes[mode][word]; ok {
+		t.digest -= overrideDigest(mode, word, old)
+	}
+	t.modes[mode][word] = keys
+	t.digest += overrideDigest(mode, word, keys)
+}
+
+/**
+ * Hashes one entry of an override table. The hashes of the
+ * entries are added up, so the digest of a table does not
+ * depend on the order its entries were set in.
+ */
+func overrideDigest(mode int, word string, keys Override) uint64 {
+	h := fnvAdd(fnvOffset, string(rune('0'+mode)))
+	for _, s := range []string{word, keys.Primary, keys.Alternate} {
+		h = fnvAdd(fnvAdd(h, s), "\x00")
+	}
+	return h
+}
+
+/**
+ * Overrides is a table of whole words whose keys are known in
+ * advance, e.g. brand names, and which the rules should not be
+ * applied to. An encoder given an Overrides with WithOverrides
+ * looks every word up in it before encoding.
+ *
+ * Entries apply to one combination of vowel and exact encoding,
+ * or to all of them. The zero value is an empty table ready to
+ * use. An Overrides is safe for concurrent use, and may be changed
+ * or reloaded while encoders use it.
+ */
+type Overrides struct {
+	/** Number of lookups, and of lookups that found the word.
+	 * Accessed atomically; kept first for alignment. */
+	lookups, hits uint64
+
+	/** The current *overrideTable; nil until the first change. */
+	table atomic.Value
+
+	/** Serializes changes to the table. */
+	mu sync.Mutex
+
+	/** File the table was loaded from, if any. */
+	path string
+
+	/** Modification time of the file when last loaded. */
+	modTime time.Time
+}
+
+/**
+ * Creates an empty override table.
+ */
+func NewOverrides() *Overrides {
+	o := &Overrides{}
+	o.table.Store(&overrideTable{})
+	return o
+}
+
+/**
+ * Creates an override table from the text file at path. Each line
+ * holds a word, its keys and, optionally, the mode the entry
+ * applies to:
+ *
+ *   # word    primary[,alternate]   [mode]
+ *   ACME      AKM
+ *   ACME      AKAM                  V1E0
+ *   NGUYEN    NKN,NN
+ *
+ * The mode is written as in a Fingerprint, "V<vowels>E<exact>"
+ * with 0 or 1 for each; without it the entry applies in every
+ * mode. Blank lines and lines starting with '#' are ignored.
+ * The file can later be read again with Reload.
+ */
+func LoadOverrides(path string) (*Overrides, error) {
+	o := NewOverrides()
+	o.path = path
+	if err := o.Reload(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+/**
+ * Sets the keys of word in every mode. Words are matched without
+ * regard to case, and after the normalization of the encoder, so
+ * that an entry for "Müller" also applies to "MULLER" when the
+ * encoder strips accents.
+ *
+ * @return an error if a key is empty or contains characters
+ * outside KEY_ALPHABET
+ */
+func (o *Overrides) Set(word, primary, alternate string) error {
+	return o.set(allModes, word, primary, alternate)
+}
+
+/**
+ * Sets the keys of word when encoding with the given vowel
+ * and exact settings. These take precedence over keys set
+ * for every mode.
+ */
+func (o *Overrides) SetFor(encodeVowels, encodeExact bool, word, primary, alternate string) error {
+	return o.set(overrideMode(encodeVowels, encodeExact), word, primary, alternate)
+}
+
+func (o *Overrides) set(mode int, word, primary, alternate string) error {
+	if err := checkOverride(word, primary, alternate); err != nil {
+		return fmt.Errorf("metaphone3: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	old := o.entries()
+	t := *old
+	t.modes[mode] = make(map[string]Override, len(old.modes[mode])+1)
+	for w, keys := range old.modes[mode] {
+		t.modes[mode][w] = keys
+	}
+	t.put(mode, strings.Map(unicode.ToUpper, word), Override{primary, alternate})
+
+	o.table.Store(&t)
+	return nil
+}
+
+/**
+ * Reads the file the table was loaded from again, replacing
+ * all entries. If the file cannot be read or parsed, the
+ * entries are left as they were.
+ */
+func (o *Overrides) Reload() error {
+	if o.path == "" {
+		return fmt.Errorf("metaphone3: override table was not loaded from a file")
+	}
+
+	f, err := os.Open(o.path)
+	if err != nil {
+		return fmt.Errorf("metaphone3: loading overrides: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("metaphone3: loading overrides: %w", err)
+	}
+
+	t, err := readOverrides(f)
+	if err != nil {
+		return fmt.Errorf("metaphone3: loading overrides from %s: %w", o.path, err)
+	}
+
+	o.mu.Lock()
+	o.table.Store(t)
+	o.modTime = info.ModTime()
+	o.mu.Unlock()
+	return nil
+}
+
+/**
+ * Reloads the file the table was loaded from if it has been
+ * modified since it was last read.
+ *
+ * @return whether the table was reloaded
+ */
+func (o *Overrides) ReloadIfChanged() (bool, error) {
+	info, err := os.Stat(o.path)
+	if err != nil {
+		return false, fmt.Errorf("metaphone3: loading overrides: %w", err)
+	}
+
+	o.mu.Lock()
+	changed := !info.ModTime().Equal(o.modTime)
+	o.mu.Unlock()
+
+	if !changed {
+		return false, nil
+	}
+	return true, o.Reload()
+}
+
+/**
+ * Checks the file the table was loaded from every interval,
+ * and reloads it when it changes, until ctx is done. Errors
+ * are passed to onError, if not nil, and the old entries are
+ * kept. Meant to be run in its own goroutine.
+ */
+func (o *Overrides) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := o.ReloadIfChanged(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+/** Returns the number of words looked up in the table. */
+func (o *Overrides) Lookups() uint64 { return atomic.LoadUint64(&o.lookups) }
+
+/** Returns the number of words whose keys came from the table. */
+func (o *Overrides) Hits() uint64 { return atomic.LoadUint64(&o.hits) }
+
+/** Returns the number of entries, counting each mode apart. */
+func (o *Overrides) Len() int {
+	n := 0
+	for _, entries := range o.entries().modes {
+		n += len(entries)
+	}
+	return n
+}
+
+/**
+ * Returns a hash of the current entries, 0 for an empty table.
+ * Changes whenever an entry is set or the table is reloaded
+ * with different entries.
+ */
+func (o *Overrides) digest() uint64 { return o.entries().digest }
+
+/**
+ * Returns a version string of the current entries, "" for an empty
+ * table. It changes whenever an entry is set or the table is reloaded
+ * with different entries, and does not depend on the order entries
+ * were set in. The entries are not part of the fingerprint of an
+ * encoder using the table, so store the version next to keys that
+ * came from it to tell apart keys made before and after a change.
+ */
+func (o *Overrides) Version() string {
+	d := o.digest()
+	if d == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016x", d)
+}
+
+/** Entries of a table nothing has been stored in. Never changed. */
+var noOverrides overrideTable
+
+/**
+ * Returns the current entries, which must not be changed.
+ */
+func (o *Overrides) entries() *overrideTable {
+	if t, ok := o.table.Load().(*overrideTable); ok {
+		return t
+	}
+	return &noOverrides
+}
+
+/**
+ * Looks up word, already upper cased and encoded as UTF-8,
+ * for the given settings in modes, the entries of the table
+ * or an overrideIndex of them.
+ */
+func (o *Overrides) lookup(modes *[allModes + 1]map[string]Override, word []byte, encodeVowels, encodeExact bool) (Override, bool) {
+	atomic.AddUint64(&o.lookups, 1)
+
+	keys, ok := modes[overrideMode(encodeVowels, encodeExact)][string(word)]
+	if !ok {
+		keys, ok = modes[allModes][string(word)]
+	}
+
+	if ok {
+		atomic.AddUint64(&o.hits, 1)
+	}
+	return keys, ok
+}
+
+/**
+ * Entries of an override table with their words normalized the way
+ * one encoder normalizes its input, so that e.g. an entry for MÜLLER
+ * matches "Müller" in an encoder that strips accents. Each encoder
+ * given a table has its own index, built when first needed and again
+ * whenever the table changes.
+ */
+type overrideIndex struct {
+	/** The current *normalizedOverrides, if built. */
+	v atomic.Value
+}
+
+/** The entries of one overrideTable, normalized. */
+type normalizedOverrides struct {
+	/** Table the entries were taken from. */
+	from *overrideTable
+
+	/** Entries by mode, with normalized words. */
+	modes [allModes + 1]map[string]Override
+}
+
+/**
+ * Returns the entries of t with their words normalized by m,
+ * building them if the index was built from another table. Where
+ * several words normalize alike, the entry whose word is already
+ * normalized wins, and otherwise the first word in sort order.
+ */
+func (x *overrideIndex) entries(t *overrideTable, m *metaph) *[allModes + 1]map[string]Override {
+	if n, ok := x.v.Load().(*normalizedOverrides); ok && n.from == t {
+		return &n.modes
+	}
+
+	n := &normalizedOverrides{from: t}
+	for mode, entries := range t.modes {
+		if len(entries) == 0 {
+			continue
+		}
+
+		words := make([]string, 0, len(entries))
+		for word := range entries {
+			words = append(words, word)
+		}
+		sort.Strings(words)
+
+		n.modes[mode] = make(map[string]Override, len(entries))
+		for _, word := range words {
+			normalized := m.normalizeEntry(word)
+			if _, taken := n.modes[mode][normalized]; !taken || word == normalized {
+				n.modes[mode][normalized] = entries[word]
+			}
+		}
+	}
+
+	x.v.Store(n)
+	return &n.modes
+}
+
+/**
+ * Parses an override file. See LoadOverrides.
+ */
+func readOverrides(r io.Reader) (*overrideTable, error) {
+	var t overrideTable
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: want word, keys and optional mode, got %q", line, text)
+		}
+
+		mode := allModes
+		if len(fields) == 3 {
+			var ok bool
+			if mode, ok = parseOverrideMode(fields[2]); !ok {
+				return nil, fmt.Errorf("line %d: bad mode %q", line, fields[2])
+			}
+		}
+
+		word := strings.Map(unicode.ToUpper, fields[0])
+		primary, alternate := fields[1], ""
+		if i := strings.IndexByte(primary, ','); i >= 0 {
+			primary, alternate = primary[:i], primary[i+1:]
+		}
+
+		if err := checkOverride(word, primary, alternate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		t.put(mode, word, Override{primary, alternate})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+/**
+ * Checks that an override entry can be used.
+ */
+func checkOverride(word, primary, alternate string) error {
+	if word == "" {
+		return fmt.Errorf("override for empty word")
+	}
+
+	if primary == "" {
+		return fmt.Errorf("override for %q has no primary key", word)
+	}
+
+	for _, key := range []string{primary, alternate} {
+		for _, r := range key {
+			if !strings.ContainsRune(KEY_ALPHABET, r) {
+				return fmt.Errorf("override key %q for %q has %q, which is not in KEY_ALPHABET", key, word, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+/** Index in overrideTable of entries for the given settings. */
+func overrideMode(encodeVowels, encodeExact bool) int {
+	return boolDigit(encodeVowels)*2 + boolDigit(encodeExact)
+}
+
+/**
+ * Parses a mode written as in a Fingerprint, e.g. "V1E0".
+ */
+func parseOverrideMode(s string) (int, bool) {
+	if len(s) != 4 || s[0] != 'V' || s[2] != 'E' || (s[1] != '0' && s[1] != '1') || (s[3] != '0' && s[3] != '1') {
+		return 0, false
+	}
+	return overrideMode(s[1] == '1', s[3] == '1'), true
+}
+
+/**
+ * Encodes the word from the encoder's override table, if
+ * it has an entry for it.
+ *
+ * @return true if encoding handled in this routine, false if not
+ */
+func (m *metaph) encode_Override() bool {
+	// look the word up once, however often it is run again
+	// (see EncodeKey), so that it is counted once and the
+	// runs agree even if the table changes in between
+	if !m.overrideLooked {
+		m.wordBuf = m.wordBuf[:0]
+		for _, r := range m.inWord {
+			m.wordBuf = appendRune(m.wordBuf, r)
+		}
+
+		t := m.overrides.entries()
+		modes := &t.modes
+		if m.normalizing() {
+			modes = m.overrideIndex.entries(t, m)
+		}
+
+		m.override, m.overrideFound = m.overrides.lookup(modes, m.wordBuf, m.encodeVowels, m.encodeExact)
+		m.overrideLooked = true
+	}
+
+	if !m.overrideFound {
+		return false
+	}
+
+	keys := m.override
+	alternate := keys.Alternate
+	if alternate == "" {
+		alternate = keys.Primary
+	}
+
+	m.metaphAdd(keys.Primary, alternate)
+	m.current = m.length
+	return true
+}
+
+/** Appends the UTF-8 encoding of r to buf. */
+func appendRune(buf []byte, r rune) []byte {
+	var tmp [utf8.UTFMax]byte
+	n := utf8.EncodeRune(tmp[:], r)
+	return append(buf, tmp[:n]...)
+}
@@ -0,0 +1,193 @@
+package metaphone3
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOverridesLookupsCountedOncePerCall(t *testing.T) {
+	o := NewOverrides()
+	if err := o.Set("ACME", "AKM", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewWithOptions(WithOverrides(o), WithKeyLength(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Schwarzenegger is longer than the key, so EncodeKey and
+	// EncodeAll run it a second time to finish the word
+	for _, call := range []struct {
+		name string
+		run  func(string)
+	}{
+		{"Encode", func(w string) { m.Encode(w) }},
+		{"EncodeKey", func(w string) { m.EncodeKey(w) }},
+		{"EncodeAll", func(w string) { m.EncodeAll(w) }},
+		{"AppendEncode", func(w string) { m.AppendEncode(nil, nil, []byte(w)) }},
+	} {
+		for _, test := range []struct {
+			word string
+			hits uint64
+		}{
+			{"Schwarzenegger", 0},
+			{"Acme", 1},
+		} {
+			lookups, hits := o.Lookups(), o.Hits()
+			call.run(test.word)
+			if got := o.Lookups() - lookups; got != 1 {
+				t.Errorf("%s(%q): %d lookups, want 1", call.name, test.word, got)
+			}
+			if got := o.Hits() - hits; got != test.hits {
+				t.Errorf("%s(%q): %d hits, want %d", call.name, test.word, got, test.hits)
+			}
+		}
+	}
+}
+
+func TestOverridesZeroValue(t *testing.T) {
+	var o Overrides
+	if n := o.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+
+	m, err := NewWithOptions(WithOverrides(&o))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantPrimary, wantAlternate := New().Encode("Acme")
+	if primary, alternate := m.Encode("Acme"); primary != wantPrimary || alternate != wantAlternate {
+		t.Errorf("Encode(Acme) = %q, %q, want %q, %q", primary, alternate, wantPrimary, wantAlternate)
+	}
+
+	if err := o.Set("acme", "AKM", "AKAM"); err != nil {
+		t.Fatal(err)
+	}
+	if n := o.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+	if primary, alternate := m.Encode("Acme"); primary != "AKM" || alternate != "AKAM" {
+		t.Errorf("Encode(Acme) = %q, %q, want AKM, AKAM", primary, alternate)
+	}
+}
+
+func TestOverridesModes(t *testing.T) {
+	o := NewOverrides()
+	if err := o.Set("ACME", "AKM", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.SetFor(true, false, "ACME", "AKAM", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range encodersForModes(t, WithOverrides(o)) {
+		want := "AKM"
+		if c := m.Config(); c.EncodeVowels && !c.EncodeExact {
+			want = "AKAM"
+		}
+		if primary, alternate := m.Encode("Acme"); primary != want || alternate != "" {
+			t.Errorf("%v: Encode(Acme) = %q, %q, want %q, \"\"", m.Config(), primary, alternate, want)
+		}
+	}
+}
+
+func TestOverridesNormalized(t *testing.T) {
+	o := NewOverrides()
+	for _, set := range [][2]string{{"Müller", "MLR"}, {"ﬁsher", "FXR"}, {"MULLER", "MLLR"}} {
+		if err := o.Set(set[0], set[1], ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	strip, err := NewWithOptions(WithOverrides(o), WithNormalization(Normalization{StripAccents: true, ExpandLigatures: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := NewWithOptions(WithOverrides(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		m          *M3
+		word, want string
+	}{
+		// MÜLLER and MULLER both strip to MULLER; the entry
+		// spelled that way wins
+		{strip, "Müller", "MLLR"},
+		{strip, "muller", "MLLR"},
+		{strip, "fisher", "FXR"},
+		{strip, "ﬁsher", "FXR"},
+		{plain, "müller", "MLR"},
+		{plain, "Muller", "MLLR"},
+		{plain, "ﬁsher", "FXR"},
+	} {
+		if primary, _ := test.m.Encode(test.word); primary != test.want {
+			t.Errorf("%v: Encode(%q) = %q, want %q", test.m.Config().Normalization, test.word, primary, test.want)
+		}
+	}
+
+	// the index follows changes to the table
+	if err := o.Set("Gómez", "KMS", ""); err != nil {
+		t.Fatal(err)
+	}
+	if primary, _ := strip.Encode("GOMEZ"); primary != "KMS" {
+		t.Errorf("Encode(GOMEZ) = %q after setting Gómez, want KMS", primary)
+	}
+}
+
+func TestOverridesSetRejectsBadKeys(t *testing.T) {
+	var o Overrides
+	for _, test := range [][3]string{
+		{"", "AKM", ""},
+		{"ACME", "", ""},
+		{"ACME", "akm", ""},
+		{"ACME", "AKM", "AK-M"},
+	} {
+		if err := o.Set(test[0], test[1], test[2]); err == nil || !strings.HasPrefix(err.Error(), "metaphone3: ") {
+			t.Errorf("Set(%q, %q, %q) error = %v, want a metaphone3 error", test[0], test[1], test[2], err)
+		}
+	}
+	if n := o.Len(); n != 0 {
+		t.Errorf("Len() = %d after rejected entries, want 0", n)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.txt")
+	write := func(text string) {
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("# word keys mode\n\nACME AKM\nACME AKAM V1E0\nnguyen NKN,NN\n")
+	o, err := LoadOverrides(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := o.Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+
+	m, err := NewWithOptions(WithOverrides(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primary, alternate := m.Encode("Nguyen"); primary != "NKN" || alternate != "NN" {
+		t.Errorf("Encode(Nguyen) = %q, %q, want NKN, NN", primary, alternate)
+	}
+
+	// a broken file leaves the entries as they were
+	write("ACME AKM V2E0\n")
+	if err := o.Reload(); err == nil {
+		t.Error("Reload() of a bad mode succeeded")
+	}
+	if n := o.Len(); n != 3 {
+		t.Errorf("Len() = %d after failed reload, want 3", n)
+	}
+}
@@ -0,0 +1,124 @@
+package metaphone3
+
+import (
+	"strings"
+	"unicode"
+)
+
+/** Characters besides white space that split a phrase into
+ * tokens by default. See Config.Separators. */
+const DEFAULT_SEPARATORS = "-"
+
+/**
+ * TokenKey holds the keys of one token of a phrase.
+ */
+type TokenKey struct {
+	/** The token, as it appears in the phrase. */
+	Token string `json:"token"`
+
+	/** Rune offsets of the token in the phrase. */
+	Span Span `json:"span"`
+
+	/** Primary key of the token, as returned by Encode. */
+	Primary string `json:"primary"`
+
+	/** Secondary key of the token, as returned by Encode. */
+	Alternate string `json:"alternate"`
+}
+
+/**
+ * PhraseKey is the result of EncodePhrase: the keys of each
+ * token, and keys for the phrase as a whole.
+ */
+type PhraseKey struct {
+	/** Keys of the tokens, in order. */
+	Tokens []TokenKey `json:"tokens"`
+
+	/** Primary keys of the tokens, concatenated. */
+	Primary string `json:"primary"`
+
+	/** Secondary keys of the tokens (their primary keys where
+	 * they have none), concatenated. Empty if the same as
+	 * Primary. */
+	Alternate string `json:"alternate"`
+}
+
+/**
+ * Encodes a phrase such as a full name one token at a time.
+ *
+ * Encode treats its input as a single word, so "Mary Ann" and
+ * "Smith-Jones" run together and the rules for the start of a
+ * word never see the later names. EncodePhrase splits the phrase
+ * at white space and at the characters of Config.Separators,
+ * and encodes each token as a word of its own. Each token key
+ * is truncated to Config.KeyLength; the phrase keys are not.
+ * Safe for concurrent use by multiple goroutines.
+ */
+func (m *M3) EncodePhrase(phrase string) PhraseKey {
+	s := m.getMetaph()
+	defer metaphPool.Put(s)
+
+	var pk PhraseKey
+	var primary, alternate strings.Builder
+
+	m.config.tokenize(phrase, func(token string, span Span) {
+		p, a := s.encode(token)
+		pk.Tokens = append(pk.Tokens, TokenKey{
+			Token:     token,
+			Span:      span,
+			Primary:   p,
+			Alternate: a,
+		})
+
+		primary.WriteString(p)
+		if a == "" {
+			a = p
+		}
+		alternate.WriteString(a)
+	})
+
+	pk.Primary = primary.String()
+	if alternate.String() != pk.Primary {
+		pk.Alternate = alternate.String()
+	}
+
+	return pk
+}
+
+/**
+ * Splits phrase at white space and at the characters of
+ * c.Separators, calling fn with each token and its rune
+ * offsets in phrase.
+ */
+func (c Config) tokenize(phrase string, fn func(token string, span Span)) {
+	separators := c.separators()
+	start, at := -1, 0
+	tokenStart := 0
+
+	for i, r := range phrase {
+		if unicode.IsSpace(r) || strings.ContainsRune(separators, r) {
+			if start >= 0 {
+				fn(phrase[tokenStart:i], Span{Start: start, End: at})
+				start = -1
+			}
+		} else if start < 0 {
+			start, tokenStart = at, i
+		}
+		at++
+	}
+
+	if start >= 0 {
+		fn(phrase[tokenStart:], Span{Start: start, End: at})
+	}
+}
+
+/**
+ * Returns the characters that split phrases, standing
+ * in DEFAULT_SEPARATORS for an empty Separators.
+ */
+func (c Config) separators() string {
+	if c.Separators == "" {
+		return DEFAULT_SEPARATORS
+	}
+	return c.Separators
+}
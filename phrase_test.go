@@ -0,0 +1,73 @@
+package metaphone3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodePhrase(t *testing.T) {
+	plain := New()
+	apostrophes, err := NewWithOptions(WithSeparators("-'/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spaces, err := NewWithOptions(WithSeparators(" "))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	smith := func(start int) TokenKey {
+		return TokenKey{"Smith", Span{start, start + 5}, "SM0", "XMT"}
+	}
+	jones := func(start int) TokenKey {
+		return TokenKey{"Jones", Span{start, start + 5}, "JNS", "ANS"}
+	}
+
+	for _, test := range []struct {
+		name   string
+		m      *M3
+		phrase string
+		want   PhraseKey
+	}{
+		{"hyphen", plain, "Smith-Jones", PhraseKey{
+			Tokens:  []TokenKey{smith(0), jones(6)},
+			Primary: "SM0JNS", Alternate: "XMTANS",
+		}},
+		{"hyphen kept", spaces, "Smith-Jones", PhraseKey{
+			Tokens:  []TokenKey{{"Smith-Jones", Span{0, 11}, "SM0JNS", "XMTJNS"}},
+			Primary: "SM0JNS", Alternate: "XMTJNS",
+		}},
+		{"apostrophe kept", plain, "O'Brien", PhraseKey{
+			Tokens:  []TokenKey{{"O'Brien", Span{0, 7}, "APRN", ""}},
+			Primary: "APRN",
+		}},
+		{"apostrophe split", apostrophes, "O'Brien", PhraseKey{
+			Tokens:  []TokenKey{{"O", Span{0, 1}, "A", ""}, {"Brien", Span{2, 7}, "PRN", ""}},
+			Primary: "APRN",
+		}},
+		{"slash", apostrophes, "Anne/Marie", PhraseKey{
+			Tokens:  []TokenKey{{"Anne", Span{0, 4}, "AN", ""}, {"Marie", Span{5, 10}, "MR", ""}},
+			Primary: "ANMR",
+		}},
+		{"no empty tokens", plain, " --Smith  - Jones- ", PhraseKey{
+			Tokens:  []TokenKey{smith(3), jones(12)},
+			Primary: "SM0JNS", Alternate: "XMTANS",
+		}},
+		// a token without an alternate adds its primary
+		// key to the joined alternate
+		{"one alternate", plain, "Mary Smith", PhraseKey{
+			Tokens:  []TokenKey{{"Mary", Span{0, 4}, "MR", ""}, smith(5)},
+			Primary: "MRSM0", Alternate: "MRXMT",
+		}},
+		{"no alternate", plain, "Mary Ann", PhraseKey{
+			Tokens:  []TokenKey{{"Mary", Span{0, 4}, "MR", ""}, {"Ann", Span{5, 8}, "AN", ""}},
+			Primary: "MRAN",
+		}},
+		{"only separators", plain, " - ", PhraseKey{}},
+		{"empty", plain, "", PhraseKey{}},
+	} {
+		if got := test.m.EncodePhrase(test.phrase); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: EncodePhrase(%q) = %+v, want %+v", test.name, test.phrase, got, test.want)
+		}
+	}
+}
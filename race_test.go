@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package metaphone3
+
+/** Whether the race detector is on. It makes sync.Pool drop items
+ * at random, so tests counting allocations cannot run. */
+const raceEnabled = true
@@ -0,0 +1,603 @@
+package metaphone3
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * RuleSet is a list of pronunciation rules written in a small rule
+ * language, which an encoder given it with WithRules tries at each
+ * letter before its built-in handler. See ParseRules for the syntax.
+ */
+type RuleSet struct {
+	/** Rules by the first letter they match, in file order. */
+	byLetter map[rune][]rule
+
+	/** Letters whose built-in handler is not used. */
+	instead map[rune]bool
+
+	/** Hash of the rules and directives as parsed, in file
+	 * order; 0 if there are none. */
+	digest uint64
+}
+
+/**
+ * One rule of a RuleSet.
+ */
+type rule struct {
+	/** The pattern and word conditions as written, reported by Explain. */
+	name string
+
+	/** Context before the letters matched. */
+	before []ruleItem
+
+	/** Letters matched and consumed, starting at the current one. */
+	match []ruleItem
+
+	/** Letters matched after match, consumed only when vowels
+	 * are not encoded. */
+	extra []ruleItem
+
+	/** Context after the letters matched. */
+	after []ruleItem
+
+	/** Whether before starts at the beginning of the word. */
+	atStart bool
+
+	/** Whether after ends at the end of the word. */
+	atEnd bool
+
+	/** Keys appended when the rule fires. */
+	main, alt string
+
+	/** Settings the rule is limited to; nil for any. */
+	encodeVowels, encodeExact *bool
+
+	/** Conditions on the word as a whole, all of which must hold. */
+	words []wordCondition
+}
+
+/**
+ * One position of a rule pattern: a letter, or
+ * one of the classes '@' (vowel) and '.' (any letter).
+ */
+type ruleToken rune
+
+const (
+	ruleVowel ruleToken = '@'
+	ruleAny   ruleToken = '.'
+)
+
+/**
+ * One item of a rule pattern: a letter or class, or a set of
+ * strings of them, any one of which may match.
+ */
+type ruleItem struct {
+	/** The strings of the set; a single letter or class is a
+	 * set of one string of length one. */
+	alts [][]ruleToken
+
+	/** Whether the item must not match where it stands; it
+	 * then takes up no letters. */
+	not bool
+}
+
+/**
+ * A condition on the whole word, such as "prefix:FRANCOIS|GARCON".
+ */
+type wordCondition struct {
+	/** The words, or the beginnings of words, to look for. */
+	words [][]ruleToken
+
+	/** Whether the word must begin with one of words rather
+	 * than be one of them. */
+	prefix bool
+
+	/** Whether none of words may match. */
+	not bool
+}
+
+/**
+ * Parses rules from r. Each line holds one rule or directive;
+ * blank lines and anything after a '#' are ignored.
+ *
+ * A rule is a pattern, "->", the keys to append and optionally
+ * the settings and word conditions it is limited to:
+ *
+ *   [^]before(match[~extra])after[$]  ->  primary[,alternate]  [vowels|novowels] [exact|approx] [[!]prefix:WORDS] [[!]word:WORDS]
+ *
+ * The letters in parentheses are consumed and encoded as the keys,
+ * "-" standing for no key; the letters after a '~' in them must be
+ * present as well but are only consumed when vowels are not encoded.
+ * The letters before and after the parentheses must be present for
+ * the rule to fire but are left to other rules. '^' and '$' tie the
+ * pattern to the start and end of the word.
+ *
+ * Besides letters, a pattern may use '@' for any vowel, '.' for any
+ * letter, and a set such as {IA|IO|E} for any one of the strings in
+ * it. A '!' before a letter, class or set turns it into a test that
+ * it is not there, e.g. "(C)!H" for a 'C' not followed by an 'H', or
+ * "!{ISA}A(CS)$" for a final "ACS" not in "ISAACS"; at either end of
+ * the word, what is not there never is. The first item of match
+ * must be a letter, and the rule is tried whenever that letter is
+ * encoded; the first rule that fires wins, and if none does the
+ * built-in handler runs.
+ *
+ * A word condition "prefix:FRANCAIS|FACADE" lets the rule fire only
+ * in words beginning with one of the words given, and "word:HUGES"
+ * only in words that are one of them; with a '!' in front, only in
+ * other words.
+ *
+ * The directive
+ *
+ *   instead LETTERS
+ *
+ * turns off the built-in handlers of the letters given, so that
+ * where no rule fires the letter is skipped without being encoded.
+ *
+ * The built-in handlers that only look at the letters around the
+ * current one, and the words they are in, are written this way in
+ * BuiltinRules, e.g. encode_Silent_B, encode_CA_To_S, encode_CS and
+ * encode_Silent_G:
+ *
+ *   DE(BT)           -> T
+ *   DOU(BT)          -> T
+ *   ^(C~A)E{S|C|M}   -> S
+ *   !{ISA}A(CS)$     -> X      !prefix:KOVACS
+ *   (G)!{G|H}.       -> -      prefix:NG
+ *
+ * @return the rules, or an error giving the line of the first
+ * problem found
+ */
+func ParseRules(r io.Reader) (*RuleSet, error) {
+	rs := &RuleSet{byLetter: map[rune][]rule{}, instead: map[rune]bool{}}
+	digest, lines := fnvOffset, 0
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		if err := rs.parseLine(text); err != nil {
+			return nil, fmt.Errorf("metaphone3: rules line %d: %w", line, err)
+		}
+		digest = fnvAdd(digest, strings.Join(strings.Fields(text), " ")+"\n")
+		lines++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("metaphone3: reading rules: %w", err)
+	}
+
+	if lines > 0 {
+		rs.digest = digest
+	}
+
+	return rs, nil
+}
+
+/** Text of builtin.rules. */
+//go:embed builtin.rules
+var builtinRulesText string
+
+/**
+ * The built-in handlers, or the parts of them, that depend only on
+ * the letters around the current one and on the word they are in,
+ * as a RuleSet parsed from builtin.rules: among others the silent
+ * 'B', most of the leading cases of 'C', 'G', 'S' and 'W', and "Mr."
+ * and "Mrs.". Handlers that look at the key built so far, or at the
+ * exception sets, are not. An encoder given them with WithRules
+ * encodes exactly as one without them, so the file is a starting
+ * point for custom rules.
+ */
+var BuiltinRules = mustParseRules("builtin.rules", builtinRulesText)
+
+/**
+ * Parses rules that are part of the package.
+ */
+func mustParseRules(name, text string) *RuleSet {
+	rs, err := ParseRules(strings.NewReader(text))
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", name, err))
+	}
+	return rs
+}
+
+/**
+ * Reads rules from the text file at path. See ParseRules.
+ */
+func LoadRules(path string) (*RuleSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("metaphone3: loading rules: %w", err)
+	}
+	defer f.Close()
+
+	return ParseRules(f)
+}
+
+/** Returns the number of rules. */
+func (rs *RuleSet) Len() int {
+	n := 0
+	for _, rules := range rs.byLetter {
+		n += len(rules)
+	}
+	return n
+}
+
+/**
+ * Parses one rule or directive.
+ */
+func (rs *RuleSet) parseLine(text string) error {
+	fields := strings.Fields(text)
+
+	if fields[0] == "instead" {
+		if len(fields) != 2 {
+			return fmt.Errorf("want \"instead LETTERS\", got %q", text)
+		}
+		for _, letter := range strings.Map(unicode.ToUpper, fields[1]) {
+			rs.instead[letter] = true
+		}
+		return nil
+	}
+
+	if len(fields) < 3 || fields[1] != "->" {
+		return fmt.Errorf("want \"pattern -> keys\", got %q", text)
+	}
+
+	r, err := parsePattern(fields[0])
+	if err != nil {
+		return err
+	}
+
+	r.main, r.alt = fields[2], fields[2]
+	if i := strings.IndexByte(fields[2], ','); i >= 0 {
+		r.main, r.alt = fields[2][:i], fields[2][i+1:]
+	}
+	for _, key := range []*string{&r.main, &r.alt} {
+		if *key == "-" {
+			*key = ""
+		}
+		for _, c := range *key {
+			if !strings.ContainsRune(KEY_ALPHABET, c) {
+				return fmt.Errorf("key %q has %q, which is not in KEY_ALPHABET", *key, c)
+			}
+		}
+	}
+
+	yes, no := true, false
+	for _, setting := range fields[3:] {
+		switch setting {
+		case "vowels":
+			r.encodeVowels = &yes
+		case "novowels":
+			r.encodeVowels = &no
+		case "exact":
+			r.encodeExact = &yes
+		case "approx":
+			r.encodeExact = &no
+		default:
+			w, err := parseWordCondition(setting)
+			if err != nil {
+				return err
+			}
+			r.words = append(r.words, w)
+			r.name += " " + setting
+		}
+	}
+
+	first := rune(r.match[0].alts[0][0])
+	rs.byLetter[first] = append(rs.byLetter[first], r)
+	return nil
+}
+
+/**
+ * Parses a word condition such as "!prefix:FRANCOIS|GARCON".
+ */
+func parseWordCondition(setting string) (wordCondition, error) {
+	var w wordCondition
+	text := strings.Map(unicode.ToUpper, setting)
+	if strings.HasPrefix(text, "!") {
+		w.not = true
+		text = text[1:]
+	}
+
+	switch {
+	case strings.HasPrefix(text, "PREFIX:"):
+		w.prefix = true
+		text = text[len("PREFIX:"):]
+	case strings.HasPrefix(text, "WORD:"):
+		text = text[len("WORD:"):]
+	default:
+		return w, fmt.Errorf("unknown setting %q", setting)
+	}
+
+	for _, word := range strings.Split(text, "|") {
+		tokens, err := parseTokens(word)
+		if err != nil {
+			return w, fmt.Errorf("setting %q: %w", setting, err)
+		}
+		if len(tokens) == 0 {
+			return w, fmt.Errorf("setting %q has an empty word", setting)
+		}
+		w.words = append(w.words, tokens)
+	}
+	return w, nil
+}
+
+/**
+ * Parses a pattern such as "^DE(BT)$".
+ */
+func parsePattern(pattern string) (rule, error) {
+	r := rule{name: pattern}
+
+	p := strings.Map(unicode.ToUpper, pattern)
+	if strings.HasPrefix(p, "^") {
+		r.atStart = true
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "$") {
+		r.atEnd = true
+		p = p[:len(p)-1]
+	}
+
+	open, closing := strings.IndexByte(p, '('), strings.IndexByte(p, ')')
+	if open < 0 || closing < open || strings.Count(p, "(") != 1 || strings.Count(p, ")") != 1 {
+		return r, fmt.Errorf("pattern %q needs one (match) group", pattern)
+	}
+
+	match, extra := p[open+1:closing], ""
+	if i := strings.IndexByte(match, '~'); i >= 0 {
+		match, extra = match[:i], match[i+1:]
+		if extra == "" || strings.IndexByte(extra, '~') >= 0 {
+			return r, fmt.Errorf("pattern %q needs letters after one '~'", pattern)
+		}
+	}
+
+	var err error
+	for _, part := range []struct {
+		text  string
+		items *[]ruleItem
+	}{{p[:open], &r.before}, {match, &r.match}, {extra, &r.extra}, {p[closing+1:], &r.after}} {
+		if *part.items, err = parseItems(part.text); err != nil {
+			return r, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+	}
+
+	if len(r.match) == 0 || r.match[0].not || len(r.match[0].alts) != 1 ||
+		r.match[0].alts[0][0] == ruleVowel || r.match[0].alts[0][0] == ruleAny {
+		return r, fmt.Errorf("pattern %q must match a letter first", pattern)
+	}
+
+	return r, nil
+}
+
+/**
+ * Parses the items of part of a pattern.
+ */
+func parseItems(text string) ([]ruleItem, error) {
+	var items []ruleItem
+	for text != "" {
+		var item ruleItem
+		if text[0] == '!' {
+			item.not = true
+			text = text[1:]
+		}
+
+		if strings.HasPrefix(text, "{") {
+			end := strings.IndexByte(text, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed %q", text)
+			}
+			for _, alt := range strings.Split(text[1:end], "|") {
+				tokens, err := parseTokens(alt)
+				if err != nil {
+					return nil, err
+				}
+				if len(tokens) == 0 {
+					return nil, fmt.Errorf("empty string in %q", text[:end+1])
+				}
+				item.alts = append(item.alts, tokens)
+			}
+			text = text[end+1:]
+		} else {
+			if text == "" {
+				return nil, fmt.Errorf("nothing after '!'")
+			}
+			_, size := utf8.DecodeRuneInString(text)
+			tokens, err := parseTokens(text[:size])
+			if err != nil {
+				return nil, err
+			}
+			item.alts = [][]ruleToken{tokens}
+			text = text[size:]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+/**
+ * Parses a string of letters and classes.
+ */
+func parseTokens(text string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	for _, c := range text {
+		if c != rune(ruleVowel) && c != rune(ruleAny) && !unicode.IsLetter(c) && c != '\'' {
+			return nil, fmt.Errorf("unexpected %q", c)
+		}
+		tokens = append(tokens, ruleToken(c))
+	}
+	return tokens, nil
+}
+
+/**
+ * Tests whether the letters of the word from at on match tokens.
+ */
+func (m *metaph) tokensAt(at int, tokens []ruleToken) bool {
+	if at < 0 || at+len(tokens) > m.length {
+		return false
+	}
+
+	for i, t := range tokens {
+		c := m.inWord[at+i]
+		switch t {
+		case ruleVowel:
+			if !isVowel(c) {
+				return false
+			}
+		case ruleAny:
+		default:
+			if c != rune(t) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/**
+ * Tests whether the items of r.before from i down match
+ * the letters of the word that end at 'at'.
+ */
+func (m *metaph) matchBefore(r *rule, i, at int) bool {
+	if i < 0 {
+		return !r.atStart || at == 0
+	}
+
+	item := &r.before[i]
+	for _, alt := range item.alts {
+		if m.tokensAt(at-len(alt), alt) {
+			if item.not {
+				return false
+			}
+			if m.matchBefore(r, i-1, at-len(alt)) {
+				return true
+			}
+		}
+	}
+	return item.not && m.matchBefore(r, i-1, at)
+}
+
+/**
+ * Tests whether the items of r.match, r.extra and r.after, from
+ * item i of them on, match the letters of the word from at on.
+ * Records in ends where the items of match and of extra end.
+ */
+func (m *metaph) matchAfter(r *rule, i, at int, ends *[2]int) bool {
+	if i == len(r.match) {
+		ends[0] = at
+	}
+	if i == len(r.match)+len(r.extra) {
+		ends[1] = at
+	}
+
+	var item *ruleItem
+	switch n := i - len(r.match) - len(r.extra); {
+	case i < len(r.match):
+		item = &r.match[i]
+	case n < 0:
+		item = &r.extra[i-len(r.match)]
+	case n < len(r.after):
+		item = &r.after[n]
+	default:
+		return !r.atEnd || at == m.length
+	}
+
+	for _, alt := range item.alts {
+		if m.tokensAt(at, alt) {
+			if item.not {
+				return false
+			}
+			if m.matchAfter(r, i+1, at+len(alt), ends) {
+				return true
+			}
+		}
+	}
+	return item.not && m.matchAfter(r, i+1, at, ends)
+}
+
+/**
+ * Tests whether the word meets condition w.
+ */
+func (m *metaph) wordMeets(w *wordCondition) bool {
+	for _, word := range w.words {
+		if (w.prefix || len(word) == m.length) && m.tokensAt(0, word) {
+			return !w.not
+		}
+	}
+	return w.not
+}
+
+/**
+ * Tests whether r fires at m.current.
+ *
+ * @return the number of letters the rule consumes, and whether it fires
+ */
+func (m *metaph) ruleFires(r *rule) (int, bool) {
+	if (r.encodeVowels != nil && *r.encodeVowels != m.encodeVowels) || (r.encodeExact != nil && *r.encodeExact != m.encodeExact) {
+		return 0, false
+	}
+
+	for i := range r.words {
+		if !m.wordMeets(&r.words[i]) {
+			return 0, false
+		}
+	}
+
+	var ends [2]int
+	if !m.matchBefore(r, len(r.before)-1, m.current) || !m.matchAfter(r, 0, m.current, &ends) {
+		return 0, false
+	}
+
+	if m.encodeVowels {
+		return ends[0] - m.current, true
+	}
+	return ends[1] - m.current, true
+}
+
+/**
+ * Encodes the current letter with the encoder's rule set,
+ * if one of its rules fires or the letter's built-in
+ * handler is turned off.
+ *
+ * @return true if encoding handled in this routine, false if not
+ */
+func (m *metaph) encode_Rules() bool {
+	c := m.charAt(m.current)
+
+	rules := m.rules.byLetter[c]
+	for i := range rules {
+		r := &rules[i]
+		consumed, ok := m.ruleFires(r)
+		if !ok {
+			continue
+		}
+
+		m.ruleName = r.name
+		m.metaphAdd(r.main, r.alt)
+		m.ruleName = ""
+		m.current += consumed
+		return true
+	}
+
+	if m.rules.instead[c] {
+		m.current++
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,335 @@
+package metaphone3
+
+import (
+	"strings"
+	"testing"
+)
+
+/**
+ * The handler each rule of builtin.rules stands for, as Explain
+ * names it. A handler that appends nothing is traced as the
+ * handler of its letter, e.g. encode_M for the silent 'M'.
+ */
+var builtinRuleHandlers = map[string]string{
+	"DE(BT)":   "encode_Silent_B",
+	"SU(BT)L":  "encode_Silent_B",
+	"SU(BT)IL": "encode_Silent_B",
+	"DOU(BT)":  "encode_Silent_B",
+
+	"^(C){T|N}":      "encode_C",
+	"^(C~A)E{S|C|M}": "encode_CA_To_S",
+	"(C~.) prefix:FRANCAIS|FRANCAIX|LINGUICA|FACADE|GONCALVES|PROVENCAL": "encode_CA_To_S",
+	"(C~OE)L@":                               "encode_CO_To_S",
+	"(C~OE)L$":                               "encode_CO_To_S",
+	"(C~OE)N{A|O}":                           "encode_CO_To_S",
+	"(C~..) prefix:FRANCOIS|MELANCON|GARCON": "encode_CO_To_S",
+	"(C~.)$ prefix:FRANCOIS|MELANCON|GARCON": "encode_CO_To_S",
+	"(CC)IA":                                 "encode_CCIA",
+	"....(CK){I|Y}$":                         "encode_CK_CG_CQ",
+	"(C{K|G|Q}{K|G|Q})":                      "encode_CK_CG_CQ",
+	"(C{K|G|Q})":                             "encode_CK_CG_CQ",
+	"(C){T|S} prefix:CONNECTICUT|INDICT|TUCSON": "encode_C",
+	"E(C)ZEMA !prefix:KOVACS":                   "encode_C",
+	"(CZ)AR":                                    "encode_CZ",
+	"(CZ)!{EMA}":                                "encode_CZ",
+	"!{ISA}A(CS)$ !prefix:KOVACS":               "encode_CS",
+
+	"^(G)N":                "encode_G",
+	"A(GG~I){A|O}":         "encode_GG",
+	"O(GG~I)A":             "encode_GG",
+	"E(GG~I){A|O}":         "encode_GG",
+	"I(GG~I)O":             "encode_GG",
+	"U(GG~I)E..":           "encode_GG",
+	"{A|O}(GG~I)$":         "encode_GG",
+	"XA(GG~E)R":            "encode_GG",
+	"RE(GG~I)E":            "encode_GG",
+	"!{SU}(GG)":            "encode_GG",
+	"(GK)":                 "encode_GK",
+	"{E|I|A}(G)M$":         "encode_G",
+	"(G)T$":                "encode_G",
+	"(G) word:HUGES":       "encode_G",
+	"(G)!{G|H}. prefix:NG": "encode_G",
+
+	"^(M)N":      "encode_M",
+	"^(MR)$":     "encode_MR_And_MRS",
+	"^(MRS)$":    "encode_MR_And_MRS",
+	"CO(MP)TROL": "encode_MPT",
+	"ACCO(MP)T":  "encode_MPT",
+
+	"(SKJ){O|U}":                          "encode_SKJ",
+	"(S.) prefix:SJ":                      "encode_SJ",
+	"(S)$ prefix:SJ":                      "encode_SJ",
+	"(S)$ prefix:LOUIS":                   "encode_Silent_French_S_Final",
+	"(S)$ prefix:YVES":                    "encode_S",
+	"^HOR(S)$":                            "encode_S",
+	"{CAMU|YPRE}(S)$":                     "encode_S",
+	"{MESNE|DEBRI|BLANC|INGRE|CANNE}(S)$": "encode_S",
+	"{CHABLI|APROPO|JACQUE|ELYSEE|OEUVRE|GEORGE|DESPRE}(S)$":                                                                               "encode_S",
+	"(S)$ prefix:ARKANSAS|FRANCAIS|CRUDITES|BRUYERES|DESCARTES|DESCHUTES|DESCHAMPS|DESROCHES|DESCHENES|RENDEZVOUS|CONTRETEMPS|DESLAURIERS": "encode_S",
+	"{AI|OI|UI}(S)$ !prefix:LOIS|LUIS":                    "encode_S",
+	"DE(S)CARTES":                                         "encode_S",
+	"DE(S){CHAM|PRES|ROCH|ROSI|JARD|MARA|CHEN|HOTE|LAUR}": "encode_S",
+	"ME(S)NES":           "encode_S",
+	"{DUQUE|DUCHE}(S)NE": "encode_S",
+	"BEAUCHE(S)NE":       "encode_S",
+	"FRE(S)NEL":          "encode_S",
+	"GRO(S)VENOR":        "encode_S",
+	"LOUI(S)VILLE":       "encode_S",
+	"ILLINOI(S)AN":       "encode_S",
+
+	"^(W)R":                        "encode_W",
+	"(WI{C|T}Z)$":                  "encode_WITZ_WICZ",
+	"(WR)":                         "encode_WR",
+	"(WH~O)!{OSH|OP|MP|RL|RT|A|P}": "encode_WH",
+	"(WH){IDE|ARD|EAD|AWK|ERD|OOK|AND|OLE|OOD|EART|OUSE|OUND|AMMER}": "encode_WH",
+	".(WH)":                      "encode_W",
+	"@(W)$":                      "encode_Eastern_European_W",
+	"{E|O}(W)SK{I|Y}":            "encode_Eastern_European_W",
+	".(W){I|A}CKI$":              "encode_Eastern_European_W",
+	".(W)IAK$":                   "encode_Eastern_European_W",
+	"(W)!{H|ICZ|ITZ} prefix:SCH": "encode_Eastern_European_W",
+	".(W)E$":                     "encode_W",
+}
+
+/**
+ * Words for the rules of builtin.rules that the golden corpus has
+ * few or no words for, and for the edges of their patterns.
+ */
+var builtinRuleWords = []string{
+	"debt", "indebted", "doubt", "redoubtable", "subtle", "subtile", "subtitle",
+	"ctenoid", "cnidarian", "Caesar", "caecum", "Caemlyn", "francais", "facade", "facadec",
+	"Linguica", "Goncalves", "Provencal", "coelacanth", "coel", "coela", "coenobite",
+	"coenocyte", "Francois", "Melanconca", "Francoisca", "Melancon", "Garcon", "focaccia",
+	"Gorecki", "Goreckie", "Gorecky", "Bocki", "mcquaid", "acquit", "acckq", "Connecticut",
+	"indictment", "Tucson", "Tucsons", "eczema", "Kovacseczema", "czar", "czech", "Kovacs",
+	"Kovacsic", "Szabolacs", "Isaacs", "acs",
+	"gnome", "gnostic", "loggia", "correggio", "Reggio", "Maggiore", "Ruggiero", "snuggies",
+	"snuggie", "Maggi", "Poggi", "exaggerate", "Reggie", "suggest", "Suggs", "egg",
+	"Biggs", "gingko", "phlegm", "diaphragm", "paradigm", "Voigt", "Huges", "Hugest",
+	"Nguyen", "Ng", "Nghi", "Nggo",
+	"mnemonic", "Mnemosyne", "Mr", "Mrs", "Mrsa", "Mr.", "comptroller", "accompt",
+	"Hammerskjold", "Skjutsa", "Sjoberg", "Sjostrand", "Sjobois", "Louis", "Yves",
+	"Hors", "Camus", "Ypres", "debris", "blancs", "Cannes", "chablis", "apropos", "Jacques",
+	"Georges", "Arkansas", "Descartes", "rendezvous", "contretemps", "Illinois", "bourgeois",
+	"Lois", "Luis", "Deschamps", "Desjardins", "Desmarais", "Duquesne", "Duchesne",
+	"Beauchesne", "Fresnel", "Grosvenor", "Louisville", "Illinoisan",
+	"wren", "write", "Filipowicz", "Horowitz", "Witz", "awry", "unwritten", "who", "whole",
+	"whoop", "whoosh", "whom", "whorl", "whort", "whoa", "whopper", "rawhide", "blowhard",
+	"hogwash", "nowhere", "Arnow", "Kowalski", "Bukowsky", "Wiwacki", "Nowicki", "Nowiak",
+	"Wiak", "Schwartz", "Schwitz", "Schwh", "Zimbabwe", "we",
+}
+
+/**
+ * Tests whether a rule of the metaph's rule set fires anywhere
+ * in word.
+ */
+func (m *metaph) anyRuleFires(word string) bool {
+	m.loadWord(word)
+	for m.current = 0; m.current < m.length; m.current++ {
+		rules := m.rules.byLetter[m.inWord[m.current]]
+		for i := range rules {
+			if _, ok := m.ruleFires(&rules[i]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/**
+ * Runs every word of the golden corpus, and words for each of the
+ * rules, through encoders with and without BuiltinRules, in every
+ * mode, and checks that the keys are the same and, where a rule may
+ * fire, that the traces are the same step for step, the rules firing
+ * exactly where the handlers they replace do.
+ */
+func TestBuiltinRulesMatchHandlers(t *testing.T) {
+	for _, rules := range BuiltinRules.byLetter {
+		for _, r := range rules {
+			if _, ok := builtinRuleHandlers[r.name]; !ok {
+				t.Fatalf("rule %s of BuiltinRules has no handler listed", r.name)
+			}
+		}
+	}
+
+	words := append(exceptionTestWords(t), builtinRuleWords...)
+
+	fired := map[string]int{}
+	for _, opts := range [][]Option{nil, {WithKeyLength(4)}} {
+		handlers := encodersForModes(t, opts...)
+		rules := encodersForModes(t, append(opts, WithRules(BuiltinRules))...)
+
+		for i := range handlers {
+			s := rules[i].getMetaph()
+			for _, word := range words {
+				wantPrimary, wantAlternate := handlers[i].Encode(word)
+				if primary, alternate := rules[i].Encode(word); primary != wantPrimary || alternate != wantAlternate {
+					t.Errorf("%v: Encode(%q) = %q, %q, handlers give %q, %q", handlers[i].Config(), word, primary, alternate, wantPrimary, wantAlternate)
+				}
+
+				// tracing is slow, so only where a rule may fire
+				if !s.anyRuleFires(word) {
+					continue
+				}
+
+				want, got := handlers[i].Explain(word), rules[i].Explain(word)
+				if len(got) != len(want) {
+					t.Errorf("%v: Explain(%q) = %v, handlers give %v", handlers[i].Config(), word, got, want)
+					continue
+				}
+
+				for j := range got {
+					step := got[j]
+					if handler, ok := builtinRuleHandlers[step.Rule]; ok {
+						fired[step.Rule]++
+						if want[j].Rule != handler {
+							t.Errorf("%v: %q: %s fired at %v, where the handlers give %v", handlers[i].Config(), word, step.Rule, step, want[j])
+						}
+						step.Rule = handler
+					}
+					if step != want[j] {
+						t.Errorf("%v: %q: step %v, handlers give %v", handlers[i].Config(), word, got[j], want[j])
+					}
+				}
+			}
+			metaphPool.Put(s)
+		}
+	}
+
+	for name := range builtinRuleHandlers {
+		if fired[name] == 0 {
+			t.Errorf("rule %s never fired", name)
+		}
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rs, err := ParseRules(strings.NewReader(`
+# comment
+de(bt) -> T      # lower case patterns are upper cased
+^(PH)@ -> F,P vowels exact
+(X)$   -> -
+instead kq
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := rs.Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+	if !rs.instead['K'] || !rs.instead['Q'] || rs.instead['X'] {
+		t.Errorf("instead = %v, want K and Q", rs.instead)
+	}
+
+	r := rs.byLetter['P'][0]
+	if r.main != "F" || r.alt != "P" || !r.atStart || r.atEnd || *r.encodeVowels != true || *r.encodeExact != true {
+		t.Errorf("^(PH)@ parsed as %+v", r)
+	}
+	if r := rs.byLetter['X'][0]; r.main != "" || r.alt != "" || !r.atEnd {
+		t.Errorf("(X)$ parsed as %+v", r)
+	}
+}
+
+/**
+ * Encodes words with one rule each, checking what sets, '!', '~'
+ * and the word conditions match.
+ */
+func TestRulePatterns(t *testing.T) {
+	for _, test := range []struct {
+		rule   string
+		vowels bool
+		word   string
+		want   string
+	}{
+		{"(B){IA|E}$ -> 0", false, "Bia", "0"},
+		{"(B){IA|E}$ -> 0", false, "Be", "0"},
+		{"(B){IA|E}$ -> 0", false, "Bi", "P"},
+		{"(B{A|OO}) -> 0", false, "Boot", "0T"},
+		{"(B{A|OO}) -> 0", false, "Bat", "0T"},
+		{"(B{A|OO}) -> 0", false, "Bet", "PT"},
+
+		// what is not there never is at the ends of the word
+		{"(B)!E -> 0", false, "Bo", "0"},
+		{"(B)!E -> 0", false, "Be", "P"},
+		{"(B)!E -> 0", false, "Ab", "A0"},
+		{"!A(B) -> 0", false, "Bo", "0"},
+		{"!A(B) -> 0", false, "Abo", "AP"},
+		{"!{TA}(B) -> 0", false, "Tab", "TP"},
+		{"!{TA}(B) -> 0", false, "Tob", "T0"},
+
+		// the letters after '~' are consumed only without vowels
+		{"(B~O)N -> 0", false, "Bon", "0N"},
+		{"(B~O)N -> 0", true, "Bon", "0AN"},
+		{"(B~O)N -> 0", false, "Bob", "PP"},
+
+		{"(B) -> 0 prefix:AB|OB", false, "Abe", "A0"},
+		{"(B) -> 0 prefix:AB|OB", false, "Obel", "A0L"},
+		{"(B) -> 0 prefix:AB|OB", false, "Ebe", "AP"},
+		{"(B) -> 0 !prefix:AB", false, "Abe", "AP"},
+		{"(B) -> 0 !prefix:AB", false, "Ebe", "A0"},
+		{"(B) -> 0 word:ABE", false, "Abe", "A0"},
+		{"(B) -> 0 word:ABE", false, "Abel", "APL"},
+		{"(B) -> 0 !word:ABE", false, "Abel", "A0L"},
+		{"(B) -> 0 prefix:A word:ABEL|OBEL", false, "Abel", "A0L"},
+		{"(B) -> 0 prefix:A word:ABEL|OBEL", false, "Obel", "APL"},
+	} {
+		rs, err := ParseRules(strings.NewReader(test.rule))
+		if err != nil {
+			t.Fatalf("ParseRules(%q): %v", test.rule, err)
+		}
+		m, err := NewWithOptions(WithRules(rs), WithEncodeVowels(test.vowels))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := m.Encode(test.word); got != test.want {
+			t.Errorf("%s, vowels %t: Encode(%q) = %q, want %q", test.rule, test.vowels, test.word, got, test.want)
+		}
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	for _, test := range []struct {
+		text, want string
+	}{
+		{"DEBT -> T", "line 1: pattern \"DEBT\" needs one (match) group"},
+		{"DE(BT -> T", "needs one (match) group"},
+		{"DE)BT( -> T", "needs one (match) group"},
+		{"D(E)(BT) -> T", "needs one (match) group"},
+		{"DE() -> T", "must match a letter first"},
+		{"(@B) -> T", "must match a letter first"},
+		{"(.B) -> T", "must match a letter first"},
+		{"D1(BT) -> T", "unexpected '1'"},
+		{"DE(B T) -> T", "want \"pattern -> keys\""},
+		{"DE(BT)", "want \"pattern -> keys\""},
+		{"DE(BT) => T", "want \"pattern -> keys\""},
+		{"DE(BT) ->", "want \"pattern -> keys\""},
+		{"DE(BT) -> t", "key \"t\" has 't', which is not in KEY_ALPHABET"},
+		{"DE(BT) -> T,Q", "key \"Q\" has 'Q'"},
+		{"DE(BT) -> T loud", "unknown setting \"loud\""},
+		{"(B)!{E -> T", "unclosed \"{E\""},
+		{"(B){E|} -> T", "empty string in \"{E|}\""},
+		{"(B){E|1} -> T", "unexpected '1'"},
+		{"(B)! -> T", "nothing after '!'"},
+		{"(!BE) -> T", "must match a letter first"},
+		{"({B|P}E) -> T", "must match a letter first"},
+		{"(B~) -> T", "needs letters after one '~'"},
+		{"(B~E~E) -> T", "needs letters after one '~'"},
+		{"(B)~E -> T", "unexpected '~'"},
+		{"(B) -> T prefix:", "setting \"prefix:\" has an empty word"},
+		{"(B) -> T word:AB|", "has an empty word"},
+		{"(B) -> T word:A1", "setting \"word:A1\": unexpected '1'"},
+		{"(B) -> T suffix:AB", "unknown setting \"suffix:AB\""},
+		{"instead", "want \"instead LETTERS\""},
+		{"instead B C", "want \"instead LETTERS\""},
+		{"# header\n\nDE(BT) -> T\nDE(BT) -> T,\x00", "line 4: key"},
+	} {
+		rs, err := ParseRules(strings.NewReader(test.text))
+		if err == nil {
+			t.Errorf("ParseRules(%q) = %d rules, want an error", test.text, rs.Len())
+			continue
+		}
+		if msg := err.Error(); !strings.HasPrefix(msg, "metaphone3: rules line ") || !strings.Contains(msg, test.want) {
+			t.Errorf("ParseRules(%q) error = %q, want it to contain %q", test.text, msg, test.want)
+		}
+	}
+}
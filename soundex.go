@@ -0,0 +1,152 @@
+package metaphone3
+
+import (
+	"unicode"
+)
+
+/**
+ * Soundex digits of the letters 'A' to 'Z'. '0' marks
+ * vowels and other letters that are not coded.
+ */
+const soundexDigits = "01230120022455012623010202"
+
+/**
+ * Refined Soundex digits of the letters 'A' to 'Z'.
+ */
+const refinedSoundexDigits = "01360240043788015936020505"
+
+/**
+ * Soundex encodes words with American Soundex: the first letter of
+ * the word followed by three digits, e.g. "Robert" -> "R163". Only
+ * the letters 'A' to 'Z' are coded; other characters are ignored.
+ *
+ * NewSoundex follows the rules the U.S. National Archives prescribe
+ * for the census: consonants with the same digit on either side of
+ * an 'H' or 'W' are coded once, e.g. "Ashcraft" -> "A261". In the
+ * simplified variant (NewSimplifiedSoundex), found in many older
+ * implementations, 'H' and 'W' separate letters like vowels do, so
+ * that the consonants on either side are both coded: "Ashcraft" ->
+ * "A226".
+ *
+ * A Soundex is safe for concurrent use.
+ */
+type Soundex struct {
+	/** Whether 'H' and 'W' separate consonants with the same
+	 * digit, so that both are coded. */
+	simplified bool
+}
+
+/**
+ * Constructor for American Soundex, with the 'H' and 'W' rule
+ * of the National Archives.
+ */
+func NewSoundex() *Soundex { return &Soundex{} }
+
+/**
+ * Constructor for simplified Soundex, without the 'H' and 'W'
+ * rule.
+ */
+func NewSimplifiedSoundex() *Soundex { return &Soundex{simplified: true} }
+
+/**
+ * Encodes word to its Soundex code. Soundex has no alternate
+ * code, so alternate is always empty; a word without any
+ * letter 'A' to 'Z' gives an empty code.
+ */
+func (s *Soundex) Encode(word string) (primary, alternate string) {
+	var code [4]byte
+	n := 0
+	var last byte
+
+	for _, r := range word {
+		c, ok := soundexLetter(r)
+		if !ok {
+			continue
+		}
+
+		if n == 0 {
+			code[0] = c
+			last = soundexDigits[c-'A']
+			n++
+			continue
+		}
+
+		if !s.simplified && (c == 'H' || c == 'W') {
+			continue
+		}
+
+		digit := soundexDigits[c-'A']
+		if digit != '0' && digit != last {
+			code[n] = digit
+			n++
+			if n == len(code) {
+				break
+			}
+		}
+		last = digit
+	}
+
+	if n == 0 {
+		return "", ""
+	}
+
+	for ; n < len(code); n++ {
+		code[n] = '0'
+	}
+	return string(code[:]), ""
+}
+
+/**
+ * RefinedSoundex encodes words with Refined Soundex: the first
+ * letter of the word followed by a digit for every letter,
+ * including the first, with runs of the same digit written once,
+ * e.g. "testing" -> "T6036084". Codes are not truncated. Only the
+ * letters 'A' to 'Z' are coded; other characters are ignored.
+ *
+ * A RefinedSoundex is safe for concurrent use.
+ */
+type RefinedSoundex struct{}
+
+/**
+ * Constructor, default.
+ */
+func NewRefinedSoundex() *RefinedSoundex { return &RefinedSoundex{} }
+
+/**
+ * Encodes word to its Refined Soundex code. Refined Soundex has
+ * no alternate code, so alternate is always empty.
+ */
+func (s *RefinedSoundex) Encode(word string) (primary, alternate string) {
+	var code []byte
+	var last byte
+
+	for _, r := range word {
+		c, ok := soundexLetter(r)
+		if !ok {
+			continue
+		}
+
+		if code == nil {
+			code = append(code, c)
+		}
+
+		digit := refinedSoundexDigits[c-'A']
+		if digit != last {
+			code = append(code, digit)
+		}
+		last = digit
+	}
+
+	return string(code), ""
+}
+
+/**
+ * Upper cases r and tests whether it is one of 'A' to 'Z'.
+ */
+func soundexLetter(r rune) (byte, bool) {
+	r = unicode.ToUpper(r)
+	if r < 'A' || r > 'Z' {
+		return 0, false
+	}
+	return byte(r), true
+}
@@ -0,0 +1,71 @@
+package metaphone3
+
+import (
+	"testing"
+)
+
+var soundexTests = []struct {
+	word               string
+	census, simplified string
+}{
+	{"Robert", "R163", "R163"},
+	{"Rupert", "R163", "R163"},
+	{"Rubin", "R150", "R150"},
+	{"Tymczak", "T522", "T522"},
+	{"Pfister", "P236", "P236"},
+	{"Honeyman", "H555", "H555"},
+	{"Lee", "L000", "L000"},
+	{"Gutierrez", "G362", "G362"},
+	{"Jackson", "J250", "J250"},
+	{"Washington", "W252", "W252"},
+
+	// the census 'H' and 'W' rule
+	{"Ashcraft", "A261", "A226"},
+	{"Ashcroft", "A261", "A226"},
+	{"Burroughs", "B620", "B622"},
+	{"Schwartz", "S632", "S632"},
+	{"Rawson", "R250", "R250"},
+	{"Bowsher", "B260", "B260"},
+
+	// case and characters outside A to Z
+	{"o'hara", "O600", "O600"},
+	{"Müller", "M460", "M460"},
+	{"  Lloyd  ", "L300", "L300"},
+	{"", "", ""},
+	{"123", "", ""},
+}
+
+func TestSoundex(t *testing.T) {
+	census, simplified := NewSoundex(), NewSimplifiedSoundex()
+	for _, test := range soundexTests {
+		if primary, alternate := census.Encode(test.word); primary != test.census || alternate != "" {
+			t.Errorf("Soundex Encode(%q) = %q, %q, want %q", test.word, primary, alternate, test.census)
+		}
+		if primary, alternate := simplified.Encode(test.word); primary != test.simplified || alternate != "" {
+			t.Errorf("simplified Soundex Encode(%q) = %q, %q, want %q", test.word, primary, alternate, test.simplified)
+		}
+	}
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	s := NewRefinedSoundex()
+	for _, test := range []struct {
+		word, code string
+	}{
+		{"testing", "T6036084"},
+		{"The", "T60"},
+		{"quick", "Q503"},
+		{"brown", "B1908"},
+		{"fox", "F205"},
+		{"jumped", "J408106"},
+		{"over", "O0209"},
+		{"lazy", "L7050"},
+		{"dogs", "D6043"},
+		{"", ""},
+		{"-", ""},
+	} {
+		if primary, alternate := s.Encode(test.word); primary != test.code || alternate != "" {
+			t.Errorf("Encode(%q) = %q, %q, want %q", test.word, primary, alternate, test.code)
+		}
+	}
+}
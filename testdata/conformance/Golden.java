@@ -0,0 +1,59 @@
+/*
+ * Regenerates golden.tsv from the Java reference implementation of
+ * Metaphone 3, version 2.1.3. Put Metaphone3.java next to this file
+ * (removing its package line, if any), then run
+ *
+ *   javac Metaphone3.java Golden.java
+ *   java Golden < golden.tsv > golden.new
+ *
+ * and copy the comment lines at the top of golden.tsv into golden.new
+ * before replacing golden.tsv with it. Only the first field of each
+ * input line is read, so golden.tsv itself serves as the word list.
+ * Then rebuild divergences.tsv from the output of TestConformance,
+ * as the divergences it lists are those from the earlier keys.
+ */
+
+import java.io.BufferedReader;
+import java.io.IOException;
+import java.io.InputStreamReader;
+import java.io.PrintStream;
+import java.nio.charset.StandardCharsets;
+
+public class Golden {
+    public static void main(String[] args) throws IOException {
+        BufferedReader in = new BufferedReader(new InputStreamReader(System.in, StandardCharsets.UTF_8));
+        PrintStream out = new PrintStream(System.out, false, "UTF-8");
+
+        // (vowels, exact) = (false,false) (false,true) (true,false) (true,true)
+        Metaphone3[] encoders = new Metaphone3[4];
+        for (int i = 0; i < encoders.length; i++) {
+            encoders[i] = new Metaphone3();
+            encoders[i].SetEncodeVowels(i >= 2);
+            encoders[i].SetEncodeExact(i % 2 == 1);
+        }
+
+        String line;
+        while ((line = in.readLine()) != null) {
+            if (line.isEmpty() || line.startsWith("#")) {
+                continue;
+            }
+
+            String word = line.split("\t", 2)[0];
+            StringBuilder b = new StringBuilder(word);
+            for (Metaphone3 m : encoders) {
+                m.SetWord(word);
+                m.Encode();
+
+                // the corpus leaves the alternate empty where it
+                // equals the primary, as Encode does in Go
+                String primary = m.GetMetaph(), alternate = m.GetAlternateMetaph();
+                if (alternate.equals(primary)) {
+                    alternate = "";
+                }
+                b.append('\t').append(primary).append(',').append(alternate);
+            }
+            out.println(b);
+        }
+        out.flush();
+    }
+}